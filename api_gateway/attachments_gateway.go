@@ -0,0 +1,662 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	// uploadTimeout bounds handlers that receive or assemble a whole file.
+	uploadTimeout = 10 * time.Minute
+	// multipartOverhead is how much larger than the file a multipart body
+	// may be, for part headers and other fields.
+	multipartOverhead = 64 << 10
+	// maxFilenameLength is the longest filename the posts service accepts.
+	maxFilenameLength = 255
+	// blobGracePeriod is how old a blob must be before it is collected. It
+	// is well above uploadTimeout, so no request still holds such a blob
+	// without having recorded it.
+	blobGracePeriod      = time.Hour
+	blobCollectBatchSize = 100
+)
+
+var errUploadTooLarge = errors.New("upload is too large")
+
+type Attachment struct {
+	AttachmentId string    `json:"attachment_id"`
+	Filename     string    `json:"filename"`
+	MimeType     string    `json:"mime_type"`
+	Size         int64     `json:"size"`
+	Width        int32     `json:"width,omitempty"`
+	Height       int32     `json:"height,omitempty"`
+	Checksum     string    `json:"checksum"`
+	CreatedAt    time.Time `json:"created_at"`
+	URL          string    `json:"url"`
+	// Missing when no thumbnail could be made, e.g. for files other than
+	// JPEG, PNG and GIF images.
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+func attachmentFromProto(attachment *posts.Attachment) Attachment {
+	result := Attachment{
+		AttachmentId: attachment.AttachmentId,
+		Filename:     attachment.Filename,
+		MimeType:     attachment.MimeType,
+		Size:         attachment.Size,
+		Width:        attachment.Width,
+		Height:       attachment.Height,
+		Checksum:     attachment.Checksum,
+		CreatedAt:    attachment.CreatedAt.AsTime(),
+		URL:          "/attachments/" + attachment.AttachmentId,
+	}
+	if attachment.ThumbnailKey != "" {
+		result.ThumbnailURL = result.URL + "/thumbnail"
+	}
+	return result
+}
+
+type Upload struct {
+	UploadId  string    `json:"upload_id"`
+	Filename  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	Received  int64     `json:"received"`
+	Checksum  string    `json:"checksum,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func uploadFromProto(upload *posts.Upload) Upload {
+	return Upload{
+		UploadId:  upload.UploadId,
+		Filename:  upload.Filename,
+		Size:      upload.Size,
+		Received:  upload.Received,
+		Checksum:  upload.Checksum,
+		CreatedAt: upload.CreatedAt.AsTime(),
+	}
+}
+
+type CreateUploadRequest struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	// Hex-encoded SHA-256 of the whole file, checked once it is complete.
+	Checksum string `json:"checksum"`
+}
+
+// newBlobKey returns a fresh key under prefix.
+func newBlobKey(prefix string) string {
+	raw := make([]byte, 16)
+	rand.Read(raw)
+	return prefix + "/" + hex.EncodeToString(raw)
+}
+
+// sanitizeFilename keeps only the base name the client sent, without control
+// characters, and at most maxFilenameLength bytes of it.
+func sanitizeFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	for len(name) > maxFilenameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	return name
+}
+
+// spoolFile copies r into a temporary file, computing its size and
+// checksum on the way. It fails with errUploadTooLarge past limit bytes.
+// The caller removes the file.
+func spoolFile(r io.Reader, limit int64) (*os.File, int64, string, error) {
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return nil, 0, "", err
+	}
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hasher), io.LimitReader(r, limit+1))
+	if err == nil && size > limit {
+		err = errUploadTooLarge
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		err = errUploadTooLarge
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, 0, "", err
+	}
+	return file, size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// respondSpoolError answers a failed spoolFile.
+func respondSpoolError(c *gin.Context, err error) {
+	if errors.Is(err, errUploadTooLarge) {
+		respondError(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", fmt.Sprintf("Files can be at most %d bytes", maxUploadSize))
+		return
+	}
+	fmt.Printf("Failed to receive file: %v\n", err)
+	respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Failed to receive file")
+}
+
+// storeAttachment checks the type of a received file, stores it with its
+// thumbnail in the blob store and records the attachment. It answers the
+// request and reports whether the attachment was created.
+func storeAttachment(c *gin.Context, client posts.PostServiceClient, ctx context.Context, file *os.File, size int64, checksum string, filename string, uploadId string) bool {
+	if size == 0 {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "File is empty")
+		return false
+	}
+	mimeType, err := sniffContentType(file)
+	if err != nil {
+		fmt.Printf("Failed to read file: %v\n", err)
+		respondError(c, http.StatusInternalServerError, "INTERNAL", "Failed to read file")
+		return false
+	}
+	if !allowedMimeTypes[mimeType] {
+		respondError(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", fmt.Sprintf("Files of type %s are not accepted", mimeType))
+		return false
+	}
+
+	req := &posts.CreateAttachmentRequest{
+		Filename: filename,
+		MimeType: mimeType,
+		Size:     size,
+		Checksum: checksum,
+		UploadId: uploadId,
+	}
+	key := newBlobKey("attachments")
+	req.StorageKey = key + "/content"
+
+	var thumbnail []byte
+	if strings.HasPrefix(mimeType, "image/") {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			fmt.Printf("Failed to read file: %v\n", err)
+			respondError(c, http.StatusInternalServerError, "INTERNAL", "Failed to read file")
+			return false
+		}
+		// Images the gateway can not decode are kept as plain files.
+		media, err := readImage(file, mimeType)
+		if err != nil {
+			fmt.Printf("Failed to decode image: %v\n", err)
+		} else {
+			req.Width, req.Height = int32(media.Width), int32(media.Height)
+			thumbnail = media.Thumbnail
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		fmt.Printf("Failed to read file: %v\n", err)
+		respondError(c, http.StatusInternalServerError, "INTERNAL", "Failed to read file")
+		return false
+	}
+	if err := blobs.Put(ctx, req.StorageKey, file, size, mimeType); err != nil {
+		fmt.Printf("Failed to store file: %v\n", err)
+		respondError(c, http.StatusServiceUnavailable, "UNAVAILABLE", "Failed to store file")
+		return false
+	}
+	if thumbnail != nil {
+		req.ThumbnailKey = key + "/thumbnail"
+		err := blobs.Put(ctx, req.ThumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), thumbnailContentType(mimeType))
+		if err != nil {
+			fmt.Printf("Failed to store thumbnail: %v\n", err)
+			req.ThumbnailKey = ""
+		}
+	}
+
+	resp, err := client.CreateAttachment(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to create attachment: %v\n", err)
+		deleteBlobs(req.StorageKey, req.ThumbnailKey)
+		respondGRPCError(c, err, "Failed to create attachment")
+		return false
+	}
+	c.JSON(http.StatusCreated, attachmentFromProto(resp.Attachment))
+	return true
+}
+
+// deleteBlobs removes blobs that are no longer needed. Failures only leave
+// unreferenced blobs behind, so they are logged and ignored.
+func deleteBlobs(keys ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := blobs.Delete(ctx, key); err != nil {
+			fmt.Printf("Failed to delete blob %s: %v\n", key, err)
+		}
+	}
+}
+
+func handleUploadAttachment(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequestWithTimeout(c, postsServiceURL, uploadTimeout)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize+multipartOverhead)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Expected a multipart/form-data body")
+		return
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Missing file field")
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		file, size, checksum, err := spoolFile(part, maxUploadSize)
+		if err != nil {
+			respondSpoolError(c, err)
+			return
+		}
+		defer os.Remove(file.Name())
+		defer file.Close()
+		storeAttachment(c, client, ctx, file, size, checksum, sanitizeFilename(part.FileName()), "")
+		return
+	}
+}
+
+func handleGetAttachment(c *gin.Context, postsServiceURL string) {
+	serveAttachment(c, postsServiceURL, false)
+}
+
+func handleGetAttachmentThumbnail(c *gin.Context, postsServiceURL string) {
+	serveAttachment(c, postsServiceURL, true)
+}
+
+// serveAttachment streams the content or the thumbnail of an attachment the
+// caller can see.
+func serveAttachment(c *gin.Context, postsServiceURL string, thumbnail bool) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	resp, err := client.GetAttachment(ctx, &posts.GetAttachmentRequest{AttachmentId: c.Param("id")})
+	if err != nil {
+		fmt.Printf("Failed to fetch attachment: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch attachment")
+		return
+	}
+	attachment := resp.Attachment
+
+	key, contentType, size, etag := attachment.StorageKey, attachment.MimeType, attachment.Size, `"`+attachment.Checksum+`"`
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			respondError(c, http.StatusNotFound, "NOT_FOUND", "Attachment has no thumbnail")
+			return
+		}
+		key, contentType, size, etag = attachment.ThumbnailKey, thumbnailContentType(attachment.MimeType), -1, `"`+attachment.Checksum+`-thumbnail"`
+	}
+
+	// Access may change with the post, so clients revalidate every time.
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	content, err := blobs.Get(c.Request.Context(), key)
+	if err != nil {
+		fmt.Printf("Failed to read blob %s: %v\n", key, err)
+		respondError(c, http.StatusServiceUnavailable, "UNAVAILABLE", "Failed to read attachment")
+		return
+	}
+	defer content.Close()
+
+	disposition := "inline"
+	if !strings.HasPrefix(contentType, "image/") {
+		disposition = "attachment"
+	}
+	c.DataFromReader(http.StatusOK, size, contentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func handleCreateUpload(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	var reqBody CreateUploadRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	if reqBody.Size > maxUploadSize {
+		respondError(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", fmt.Sprintf("Files can be at most %d bytes", maxUploadSize))
+		return
+	}
+
+	resp, err := client.CreateUpload(ctx, &posts.CreateUploadRequest{
+		Filename: sanitizeFilename(reqBody.Filename),
+		Size:     reqBody.Size,
+		Checksum: strings.ToLower(reqBody.Checksum),
+	})
+	if err != nil {
+		fmt.Printf("Failed to create upload: %v\n", err)
+		respondGRPCError(c, err, "Failed to create upload")
+		return
+	}
+	c.JSON(http.StatusCreated, uploadFromProto(resp.Upload))
+}
+
+func handleGetUpload(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	resp, err := client.GetUpload(ctx, &posts.GetUploadRequest{UploadId: c.Param("id")})
+	if err != nil {
+		fmt.Printf("Failed to fetch upload: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch upload")
+		return
+	}
+	c.JSON(http.StatusOK, uploadFromProto(resp.Upload))
+}
+
+// parseContentRange parses a "bytes <first>-<last>/<total>" Content-Range
+// header.
+func parseContentRange(header string) (first int64, last int64, total int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes ")
+	if !found {
+		return 0, 0, 0, false
+	}
+	rangeSpec, totalSpec, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, 0, false
+	}
+	firstSpec, lastSpec, found := strings.Cut(rangeSpec, "-")
+	if !found {
+		return 0, 0, 0, false
+	}
+	first, err1 := strconv.ParseInt(firstSpec, 10, 64)
+	last, err2 := strconv.ParseInt(lastSpec, 10, 64)
+	total, err3 := strconv.ParseInt(totalSpec, 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil || first < 0 || last < first || last >= total {
+		return 0, 0, 0, false
+	}
+	return first, last, total, true
+}
+
+// handleUploadChunk stores the chunk given by Content-Range. The chunk must
+// start where the upload stopped; the last one turns the upload into an
+// attachment. If that fails, "Content-Range: bytes */<size>" without a body
+// retries it.
+func handleUploadChunk(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequestWithTimeout(c, postsServiceURL, uploadTimeout)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	header := c.GetHeader("Content-Range")
+	first, last, total, ok := parseContentRange(header)
+	finishing := false
+	if !ok {
+		total, ok = parseCompletedRange(header)
+		finishing = true
+	}
+	if !ok {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Expected a Content-Range header like bytes 0-1023/4096")
+		return
+	}
+	size := last - first + 1
+	if finishing {
+		size = 0
+	}
+	if c.Request.ContentLength != size {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Content-Length does not match Content-Range")
+		return
+	}
+
+	uploadId := c.Param("id")
+	current, err := client.GetUpload(ctx, &posts.GetUploadRequest{UploadId: uploadId})
+	if err != nil {
+		fmt.Printf("Failed to fetch upload: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch upload")
+		return
+	}
+	upload := current.Upload
+	if total != upload.Size {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("Upload size is %d bytes", upload.Size))
+		return
+	}
+	if finishing {
+		if upload.Received < upload.Size {
+			respondError(c, http.StatusConflict, "ABORTED", fmt.Sprintf("Upload continues at offset %d", upload.Received))
+			return
+		}
+		finishUpload(c, client, ctx, upload)
+		return
+	}
+	if first != upload.Received {
+		message := fmt.Sprintf("Upload continues at offset %d", upload.Received)
+		if upload.Received == upload.Size {
+			message = fmt.Sprintf("Upload is complete, finish it with Content-Range bytes */%d", upload.Size)
+		}
+		respondError(c, http.StatusConflict, "ABORTED", message)
+		return
+	}
+
+	key := newBlobKey("uploads/" + uploadId)
+	if err := blobs.Put(ctx, key, c.Request.Body, size, "application/octet-stream"); err != nil {
+		fmt.Printf("Failed to store chunk: %v\n", err)
+		deleteBlobs(key)
+		respondError(c, http.StatusServiceUnavailable, "UNAVAILABLE", "Failed to store chunk")
+		return
+	}
+	resp, err := client.AppendUploadChunk(ctx, &posts.AppendUploadChunkRequest{
+		UploadId:   uploadId,
+		Offset:     first,
+		Size:       size,
+		StorageKey: key,
+	})
+	if err != nil {
+		fmt.Printf("Failed to append chunk: %v\n", err)
+		deleteBlobs(key)
+		respondGRPCError(c, err, "Failed to append chunk")
+		return
+	}
+	upload = resp.Upload
+	if upload.Received < upload.Size {
+		c.JSON(http.StatusOK, uploadFromProto(upload))
+		return
+	}
+	finishUpload(c, client, ctx, upload)
+}
+
+// parseCompletedRange parses a "bytes */<total>" Content-Range header, which
+// asks to finish an upload whose chunks have all been received.
+func parseCompletedRange(header string) (total int64, ok bool) {
+	totalSpec, found := strings.CutPrefix(header, "bytes */")
+	if !found {
+		return 0, false
+	}
+	total, err := strconv.ParseInt(totalSpec, 10, 64)
+	if err != nil || total <= 0 {
+		return 0, false
+	}
+	return total, true
+}
+
+// finishUpload joins the chunks of a complete upload into an attachment. The
+// upload is kept until the attachment is created, so a failed attempt can be
+// repeated; the chunks are deleted once it succeeds.
+func finishUpload(c *gin.Context, client posts.PostServiceClient, ctx context.Context, upload *posts.Upload) {
+	chunks := &chunkReader{ctx: ctx, keys: upload.ChunkKeys}
+	defer chunks.Close()
+	file, fileSize, checksum, err := spoolFile(chunks, maxUploadSize)
+	if err != nil {
+		fmt.Printf("Failed to join chunks: %v\n", err)
+		respondError(c, http.StatusServiceUnavailable, "UNAVAILABLE", "Failed to read uploaded chunks")
+		return
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if upload.Checksum != "" && upload.Checksum != checksum {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Checksum of the uploaded file does not match")
+		return
+	}
+	if storeAttachment(c, client, ctx, file, fileSize, checksum, upload.Filename, upload.UploadId) {
+		deleteBlobs(upload.ChunkKeys...)
+	}
+}
+
+// BlobCollector deletes blobs that no attachment or upload refers to: those
+// of attachments and uploads the posts service removed, and chunks or files
+// left behind by failed requests. Blobs younger than blobGracePeriod are
+// skipped, since a request may be about to record them.
+type BlobCollector struct {
+	PostsServiceURL string
+	Interval        time.Duration
+}
+
+// Run collects every Interval until the process exits.
+func (c *BlobCollector) Run() {
+	for {
+		if err := c.collect(); err != nil {
+			fmt.Printf("Failed to collect blobs: %v\n", err)
+		}
+		time.Sleep(c.Interval)
+	}
+}
+
+func (c *BlobCollector) collect() error {
+	conn, err := grpc.NewClient(c.PostsServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := posts.NewPostServiceClient(conn)
+
+	ctx := context.Background()
+	cutoff := time.Now().Add(-blobGracePeriod)
+	var batch []string
+	deleted := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		resp, err := client.GetReferencedBlobKeys(checkCtx, &posts.GetReferencedBlobKeysRequest{StorageKeys: batch})
+		if err != nil {
+			return err
+		}
+		referenced := make(map[string]bool, len(resp.StorageKeys))
+		for _, key := range resp.StorageKeys {
+			referenced[key] = true
+		}
+		for _, key := range batch {
+			if referenced[key] {
+				continue
+			}
+			if err := blobs.Delete(ctx, key); err != nil {
+				return err
+			}
+			deleted++
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	err = blobs.Walk(ctx, func(key string, modified time.Time) error {
+		if modified.After(cutoff) {
+			return nil
+		}
+		batch = append(batch, key)
+		if len(batch) < blobCollectBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if deleted > 0 {
+		fmt.Printf("Deleted %d unreferenced blobs\n", deleted)
+	}
+	return err
+}
+
+// chunkReader reads the blobs under keys one after another.
+type chunkReader struct {
+	ctx     context.Context
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+			current, err := blobs.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, err
+			}
+			r.current, r.keys = current, r.keys[1:]
+		}
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() {
+	if r.current != nil {
+		r.current.Close()
+	}
+}
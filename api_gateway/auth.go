@@ -0,0 +1,291 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// jwksTTL is how long fetched keys are trusted before being refetched.
+	jwksTTL = 5 * time.Minute
+	// jwksMinRefetchInterval limits refetches triggered by unknown kids, so
+	// garbage tokens can not be used to hammer passport.
+	jwksMinRefetchInterval = 10 * time.Second
+	// revocationPollInterval bounds how long a session revoked through
+	// another gateway instance stays usable here.
+	revocationPollInterval = 5 * time.Second
+)
+
+var httpClient = &http.Client{Timeout: 5 * time.Second}
+
+// KeySet caches the public keys passport publishes at
+// /.well-known/jwks.json. When passport is unreachable the last fetched keys
+// keep being used.
+type KeySet struct {
+	url string
+
+	mu          sync.Mutex
+	keys        map[string]keyEntry
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	// fetching is closed when the fetch in flight, if any, completes.
+	fetching chan struct{}
+}
+
+type keyEntry struct {
+	alg string
+	key any
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+func NewKeySet(passportURL string) *KeySet {
+	return &KeySet{url: passportURL + "/.well-known/jwks.json"}
+}
+
+// Keyfunc picks the key named by the token's kid header, refetching the key
+// set when it is stale or does not know the kid yet.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	entry, ok := ks.lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != entry.alg {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+	return entry.key, nil
+}
+
+// lookup returns the key for kid. Keys are fetched outside of ks.mu, so a
+// slow passport never blocks requests whose keys are cached: a stale key set
+// is refreshed in the background, and only lookups of unknown kids wait for
+// the single fetch in flight.
+func (ks *KeySet) lookup(kid string) (keyEntry, bool) {
+	ks.mu.Lock()
+	entry, ok := ks.keys[kid]
+	stale := time.Since(ks.fetchedAt) > jwksTTL
+	if ok && !stale {
+		ks.mu.Unlock()
+		return entry, ok
+	}
+	if ks.fetching == nil && time.Since(ks.attemptedAt) >= jwksMinRefetchInterval {
+		ks.attemptedAt = time.Now()
+		ks.fetching = make(chan struct{})
+		go ks.refresh(ks.fetching)
+	}
+	fetching := ks.fetching
+	ks.mu.Unlock()
+	if ok || fetching == nil {
+		return entry, ok
+	}
+
+	<-fetching
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	entry, ok = ks.keys[kid]
+	return entry, ok
+}
+
+// refresh fetches the key set and swaps it in, closing done when finished.
+func (ks *KeySet) refresh(done chan struct{}) {
+	keys, err := fetchKeys(ks.url)
+	ks.mu.Lock()
+	if err != nil {
+		fmt.Printf("Failed to fetch JWKS, using cached keys: %v\n", err)
+	} else {
+		ks.keys = keys
+		ks.fetchedAt = time.Now()
+	}
+	ks.fetching = nil
+	ks.mu.Unlock()
+	close(done)
+}
+
+func fetchKeys(jwksURL string) (map[string]keyEntry, error) {
+	resp, err := httpClient.Get(jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	var body struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]keyEntry, len(body.Keys))
+	for _, k := range body.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			fmt.Printf("Skipping key %q: %v\n", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = keyEntry{alg: k.Alg, key: key}
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch {
+	case k.Kty == "RSA" && k.Alg == "RS256":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case k.Kty == "OKP" && k.Crv == "Ed25519" && k.Alg == "EdDSA":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key length %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s/%s", k.Kty, k.Alg)
+	}
+}
+
+// RevocationChecker tells whether a session has been revoked, i.e. whether
+// access tokens issued for it must be rejected before they expire.
+type RevocationChecker interface {
+	IsRevoked(sessionID string) bool
+}
+
+// PolledDenylist mirrors the sessions passport reports as revoked. It polls
+// passport in the background and can be synced on demand right after the
+// gateway proxies a request that revokes sessions, so a client never sees its
+// own logout lag behind.
+type PolledDenylist struct {
+	url string
+
+	mu      sync.RWMutex
+	revoked map[string]time.Time
+	since   string
+	syncMu  sync.Mutex
+}
+
+func NewPolledDenylist(passportURL string) *PolledDenylist {
+	return &PolledDenylist{
+		url:     passportURL + "/revoked_sessions",
+		revoked: make(map[string]time.Time),
+	}
+}
+
+func (d *PolledDenylist) IsRevoked(sessionID string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.revoked[sessionID]
+	return ok
+}
+
+// Run polls passport every interval until the process exits.
+func (d *PolledDenylist) Run(interval time.Duration) {
+	for {
+		if err := d.Sync(); err != nil {
+			fmt.Printf("Failed to sync revoked sessions: %v\n", err)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Sync fetches sessions revoked since the previous sync and forgets entries
+// whose tokens have all expired.
+func (d *PolledDenylist) Sync() error {
+	d.syncMu.Lock()
+	defer d.syncMu.Unlock()
+
+	syncURL := d.url
+	if d.since != "" {
+		syncURL += "?since=" + url.QueryEscape(d.since)
+	}
+	resp, err := httpClient.Get(syncURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	var body struct {
+		Sessions []struct {
+			SessionId string    `json:"session_id"`
+			Until     time.Time `json:"until"`
+		} `json:"sessions"`
+		AsOf time.Time `json:"as_of"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	for _, session := range body.Sessions {
+		d.revoked[session.SessionId] = session.Until
+	}
+	for sessionID, until := range d.revoked {
+		if until.Before(now) {
+			delete(d.revoked, sessionID)
+		}
+	}
+	d.mu.Unlock()
+	d.since = body.AsOf.Format(time.RFC3339Nano)
+	return nil
+}
+
+// TokenVerifier checks access tokens without calling passport on the
+// request path.
+type TokenVerifier struct {
+	Keys       *KeySet
+	Revocation RevocationChecker
+}
+
+func (v *TokenVerifier) Verify(tokenString string) (user_id string, session_id string, err error) {
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+	token, err := jwt.Parse(tokenString, v.Keys.Keyfunc)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse token: %v", err)
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", "", fmt.Errorf("invalid token")
+	}
+	user_id, _ = claims["user_id"].(string)
+	session_id, _ = claims["session_id"].(string)
+	if user_id == "" || session_id == "" {
+		return "", "", fmt.Errorf("token has no user or session ID")
+	}
+	if v.Revocation.IsRevoked(session_id) {
+		return "", "", fmt.Errorf("session %s is revoked", session_id)
+	}
+	return user_id, session_id, nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+
+	"msg.i3cheese.ru/proto/passport"
+)
+
+const (
+	// authorCacheTTL is how long a resolved author is reused, so profile
+	// changes show up in posts within this time.
+	authorCacheTTL = 30 * time.Second
+	// maxAuthorCacheSize bounds the number of cached authors.
+	maxAuthorCacheSize = 10000
+	// authorLookupTimeout bounds how long a response waits for passport.
+	authorLookupTimeout = 300 * time.Millisecond
+)
+
+// expandableFields are the values accepted in the expand query parameter.
+var expandableFields = map[string]bool{"author": true}
+
+// authorCache is set up with the passport routes.
+var authorCache *AuthorCache
+
+// Author is the part of a user's profile embedded into posts and comments.
+type Author struct {
+	UserId      string `json:"user_id"`
+	Login       string `json:"login"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url"`
+}
+
+type authorEntry struct {
+	author    Author
+	expiresAt time.Time
+}
+
+// AuthorCache resolves user ids to authors, asking passport once per request
+// for all ids that are not cached.
+type AuthorCache struct {
+	Client passport.PassportServiceClient
+	TTL    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]authorEntry
+}
+
+func NewAuthorCache(client passport.PassportServiceClient, ttl time.Duration) *AuthorCache {
+	return &AuthorCache{Client: client, TTL: ttl, entries: make(map[string]authorEntry)}
+}
+
+// Lookup returns the authors of the given users. If passport fails, only
+// cached authors are returned.
+func (a *AuthorCache) Lookup(ctx context.Context, userIds []string) map[string]Author {
+	now := time.Now()
+	result := make(map[string]Author, len(userIds))
+	var missing []string
+	seen := make(map[string]bool, len(userIds))
+
+	a.mu.Lock()
+	for _, userId := range userIds {
+		if seen[userId] || userId == "" {
+			continue
+		}
+		seen[userId] = true
+		if entry, ok := a.entries[userId]; ok && now.Before(entry.expiresAt) {
+			result[userId] = entry.author
+		} else {
+			missing = append(missing, userId)
+		}
+	}
+	a.mu.Unlock()
+	if len(missing) == 0 {
+		return result
+	}
+
+	resp, err := a.Client.BatchGetProfiles(ctx, &passport.BatchGetProfilesRequest{UserIds: missing})
+	if err != nil {
+		fmt.Printf("Failed to fetch authors: %v\n", err)
+		return result
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.entries)+len(resp.Profiles) > maxAuthorCacheSize {
+		for userId, entry := range a.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(a.entries, userId)
+			}
+		}
+		if len(a.entries)+len(resp.Profiles) > maxAuthorCacheSize {
+			a.entries = make(map[string]authorEntry)
+		}
+	}
+	for _, profile := range resp.Profiles {
+		author := Author{
+			UserId:      profile.UserId,
+			Login:       profile.Login,
+			DisplayName: profile.DisplayName,
+			AvatarUrl:   profile.AvatarUrl,
+		}
+		a.entries[profile.UserId] = authorEntry{author: author, expiresAt: now.Add(a.TTL)}
+		result[profile.UserId] = author
+	}
+	return result
+}
+
+// expandMiddleware rejects unknown values of the expand query parameter
+// before the request reaches any service.
+func expandMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, field := range expandedFields(c) {
+			if !expandableFields[field] {
+				respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("Invalid expand value %q, expected author", field))
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// expandedFields returns the comma separated values of the expand query
+// parameter.
+func expandedFields(c *gin.Context) []string {
+	var fields []string
+	for _, value := range c.QueryArray("expand") {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+func expandsAuthor(c *gin.Context) bool {
+	for _, field := range expandedFields(c) {
+		if field == "author" {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupAuthors resolves the given creators if the request asked for
+// ?expand=author. Missing entries mean the author could not be resolved and
+// the response carries only the creator id.
+func lookupAuthors(c *gin.Context, creatorIds []string) map[string]Author {
+	if authorCache == nil || len(creatorIds) == 0 || !expandsAuthor(c) {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), authorLookupTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "request_id", requestId(c))
+	return authorCache.Lookup(ctx, creatorIds)
+}
+
+// fillPostAuthors sets Author of the posts if the request asked for it.
+func fillPostAuthors(c *gin.Context, postsList []Post) {
+	creatorIds := make([]string, 0, len(postsList))
+	for _, post := range postsList {
+		creatorIds = append(creatorIds, post.CreatorId)
+	}
+	authors := lookupAuthors(c, creatorIds)
+	for i := range postsList {
+		if author, ok := authors[postsList[i].CreatorId]; ok {
+			postsList[i].Author = &author
+		}
+	}
+}
+
+// fillCommentAuthors sets Author of the comments if the request asked for
+// it.
+func fillCommentAuthors(c *gin.Context, comments []Comment) {
+	creatorIds := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		creatorIds = append(creatorIds, comment.CreatorId)
+	}
+	authors := lookupAuthors(c, creatorIds)
+	for i := range comments {
+		if author, ok := authors[comments[i].CreatorId]; ok {
+			comments[i].Author = &author
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrBlobNotFound is returned by BlobStore.Get for keys that hold no blob.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps the content of attachments and upload chunks. Keys are
+// slash-separated paths made of the gateway's own random ids.
+type BlobStore interface {
+	// Put stores size bytes read from r under key, replacing any blob stored
+	// there. It fails if r ends early.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the blob stored under key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob; deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+	// Walk calls fn with the key of every stored blob and the time it was
+	// written, stopping at the first error.
+	Walk(ctx context.Context, fn func(key string, modified time.Time) error) error
+}
+
+// blobs is where attachments are stored. It is set up in main before the
+// router starts.
+var blobs BlobStore
+
+// newBlobStore creates the store selected by BLOB_STORE: "fs" (the default)
+// keeps blobs under BLOB_DIR, "s3" in the S3_BUCKET of an S3-compatible
+// service at S3_ENDPOINT.
+func newBlobStore() BlobStore {
+	switch os.Getenv("BLOB_STORE") {
+	case "", "fs":
+		dir := os.Getenv("BLOB_DIR")
+		if dir == "" {
+			dir = "blobs"
+		}
+		return &FSBlobStore{Dir: dir}
+	case "s3":
+		store := &S3BlobStore{
+			Endpoint:  strings.TrimSuffix(os.Getenv("S3_ENDPOINT"), "/"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		}
+		if store.Endpoint == "" || store.Bucket == "" {
+			fmt.Println("S3_ENDPOINT and S3_BUCKET are required")
+			os.Exit(1)
+		}
+		if store.Region == "" {
+			store.Region = "us-east-1"
+		}
+		// The store may start together with the gateway, so give it time.
+		var err error
+		for i := 0; i < 10; i++ {
+			if err = store.EnsureBucket(context.Background()); err == nil {
+				return store
+			}
+			fmt.Printf("Attempt %d: Unable to create bucket: %v\n", i+1, err)
+			time.Sleep(2 * time.Second)
+		}
+		fmt.Printf("Unable to create bucket after retries: %v\n", err)
+		os.Exit(1)
+		return nil
+	default:
+		fmt.Printf("Unknown BLOB_STORE %q, expected fs or s3\n", os.Getenv("BLOB_STORE"))
+		os.Exit(1)
+		return nil
+	}
+}
+
+// FSBlobStore keeps every blob in a file under Dir.
+type FSBlobStore struct {
+	Dir string
+}
+
+func (s *FSBlobStore) path(key string) (string, error) {
+	if key == "" || !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(key)), nil
+}
+
+func (s *FSBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first, so readers never see half a blob.
+	// Delete may remove the directory while it is empty, so try again if it
+	// disappears before the file is created.
+	var tmp *os.File
+	for attempt := 1; ; attempt++ {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		tmp, err = os.CreateTemp(filepath.Dir(path), ".tmp-*")
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) || attempt == 3 {
+			return err
+		}
+	}
+	defer os.Remove(tmp.Name())
+	written, err := io.Copy(tmp, io.LimitReader(r, size))
+	if err == nil && written != size {
+		err = fmt.Errorf("blob ended after %d of %d bytes", written, size)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *FSBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	return file, err
+}
+
+func (s *FSBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	// Remove directories left empty; removing one that is not fails.
+	for dir := filepath.Dir(path); dir != filepath.Clean(s.Dir); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *FSBlobStore) Walk(ctx context.Context, fn func(key string, modified time.Time) error) error {
+	return filepath.WalkDir(s.Dir, func(path string, entry fs.DirEntry, err error) error {
+		// Nothing may have been stored yet, or a directory may have been
+		// removed since it was listed.
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		key, err := filepath.Rel(s.Dir, path)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(key), info.ModTime())
+	})
+}
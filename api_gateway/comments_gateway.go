@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+type CreateCommentRequest struct {
+	ParentCommentId string `json:"parent_comment_id"`
+	Content         string `json:"content"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content"`
+}
+
+type Comment struct {
+	CommentId       string    `json:"comment_id"`
+	PostId          string    `json:"post_id"`
+	ParentCommentId string    `json:"parent_comment_id,omitempty"`
+	CreatorId       string    `json:"creator_id"`
+	Content         string    `json:"content"`
+	ReplyCount      int32     `json:"reply_count"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	// Only set with ?expand=author, and missing if passport is unavailable.
+	Author *Author `json:"author,omitempty"`
+}
+
+func commentFromProto(comment *posts.Comment) Comment {
+	return Comment{
+		CommentId:       comment.CommentId,
+		PostId:          comment.PostId,
+		ParentCommentId: comment.ParentCommentId,
+		CreatorId:       comment.CreatorId,
+		Content:         comment.Content,
+		ReplyCount:      comment.ReplyCount,
+		CreatedAt:       comment.CreatedAt.AsTime(),
+		UpdatedAt:       comment.UpdatedAt.AsTime(),
+	}
+}
+
+func handleCreateComment(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	var reqBody CreateCommentRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	if reqBody.Content == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Comment content is required")
+		return
+	}
+
+	req := &posts.CreateCommentRequest{
+		PostId:          c.Param("id"),
+		ParentCommentId: reqBody.ParentCommentId,
+		Content:         reqBody.Content,
+	}
+	resp, err := client.CreateComment(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to create comment: %v\n", err)
+		respondGRPCError(c, err, "Failed to create comment")
+		return
+	}
+	comment := []Comment{commentFromProto(resp.Comment)}
+	fillCommentAuthors(c, comment)
+	c.JSON(http.StatusCreated, comment[0])
+}
+
+func handleUpdateComment(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	var reqBody UpdateCommentRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	if reqBody.Content == "" {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Comment content is required")
+		return
+	}
+
+	req := &posts.UpdateCommentRequest{
+		PostId:    c.Param("id"),
+		CommentId: c.Param("comment_id"),
+		Content:   reqBody.Content,
+	}
+	resp, err := client.UpdateComment(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to update comment: %v\n", err)
+		respondGRPCError(c, err, "Failed to update comment")
+		return
+	}
+	comment := []Comment{commentFromProto(resp.Comment)}
+	fillCommentAuthors(c, comment)
+	c.JSON(http.StatusOK, comment[0])
+}
+
+func handleDeleteComment(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.DeleteCommentRequest{
+		PostId:    c.Param("id"),
+		CommentId: c.Param("comment_id"),
+	}
+	_, err = client.DeleteComment(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to delete comment: %v\n", err)
+		respondGRPCError(c, err, "Failed to delete comment")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func handleListComments(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.ListCommentsRequest{
+		PostId:          c.Param("id"),
+		ParentCommentId: c.Query("parent_comment_id"),
+		Cursor:          c.Query("cursor"),
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.ListComments(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch comments: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch comments")
+		return
+	}
+
+	comments := make([]Comment, 0, len(resp.Comments))
+	for _, comment := range resp.Comments {
+		comments = append(comments, commentFromProto(comment))
+	}
+	fillCommentAuthors(c, comments)
+	c.JSON(http.StatusOK, gin.H{"comments": comments, "next_cursor": resp.NextCursor})
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+// postStatuses maps the status names of the API to PostStatus. A missing
+// status creates a published post.
+var postStatuses = map[string]posts.PostStatus{
+	"":          posts.PostStatus_POST_STATUS_PUBLISHED,
+	"published": posts.PostStatus_POST_STATUS_PUBLISHED,
+	"draft":     posts.PostStatus_POST_STATUS_DRAFT,
+	"scheduled": posts.PostStatus_POST_STATUS_SCHEDULED,
+}
+
+var postStatusNames = map[posts.PostStatus]string{
+	posts.PostStatus_POST_STATUS_PUBLISHED: "published",
+	posts.PostStatus_POST_STATUS_DRAFT:     "draft",
+	posts.PostStatus_POST_STATUS_SCHEDULED: "scheduled",
+}
+
+type PublishPostRequest struct {
+	// Schedules the post instead when in the future.
+	PublishAt *time.Time `json:"publish_at"`
+}
+
+func handlePublishPost(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	// The body is optional: without it the post is published right away.
+	var reqBody PublishPostRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			fmt.Printf("Failed to bind JSON: %v\n", err)
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+			return
+		}
+	}
+	req := &posts.PublishPostRequest{PostId: c.Param("id")}
+	if reqBody.PublishAt != nil {
+		req.PublishAt = timestamppb.New(*reqBody.PublishAt)
+	}
+
+	resp, err := client.PublishPost(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to publish post: %v\n", err)
+		respondGRPCError(c, err, "Failed to publish post")
+		return
+	}
+	post := []Post{postFromProto(resp.Post)}
+	fillPostAuthors(c, post)
+	c.Header("ETag", postETag(post[0].UpdatedAt))
+	c.JSON(http.StatusOK, post[0])
+}
+
+func handleListMyDrafts(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.ListMyDraftsRequest{Cursor: c.Query("cursor")}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.ListMyDrafts(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch drafts: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch drafts")
+		return
+	}
+
+	drafts := make([]Post, 0, len(resp.Posts))
+	for _, post := range resp.Posts {
+		drafts = append(drafts, postFromProto(post))
+	}
+	fillPostAuthors(c, drafts)
+	c.JSON(http.StatusOK, gin.H{"posts": drafts, "next_cursor": resp.NextCursor})
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const requestIdHeader = "X-Request-Id"
+
+// ErrorResponse is the body of every error the gateway itself answers with.
+// Error is a human readable message, Code a stable identifier to match on.
+type ErrorResponse struct {
+	Error     string        `json:"error"`
+	Code      string        `json:"code"`
+	RequestId string        `json:"request_id"`
+	Details   []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorDetail carries the errdetails payloads of a gRPC status that are
+// useful to clients.
+type ErrorDetail struct {
+	Field        string `json:"field,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+	ResourceName string `json:"resource_name,omitempty"`
+}
+
+// requestIdMiddleware makes sure every request has an id, taking the
+// caller's X-Request-Id if present, and echoes it in the response.
+func requestIdMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(requestIdHeader)
+		if requestId == "" {
+			raw := make([]byte, 16)
+			rand.Read(raw)
+			requestId = hex.EncodeToString(raw)
+			c.Request.Header.Set(requestIdHeader, requestId)
+		}
+		c.Set("request_id", requestId)
+		c.Header(requestIdHeader, requestId)
+		c.Next()
+	}
+}
+
+func requestId(c *gin.Context) string {
+	return c.GetString("request_id")
+}
+
+func respondError(c *gin.Context, httpStatus int, code string, message string) {
+	c.JSON(httpStatus, ErrorResponse{Error: message, Code: code, RequestId: requestId(c)})
+}
+
+// grpcErrorCodes maps gRPC codes to the HTTP status and error code clients
+// see. Codes missing here are answered with 500 INTERNAL.
+var grpcErrorCodes = map[codes.Code]struct {
+	httpStatus int
+	code       string
+}{
+	codes.InvalidArgument:    {http.StatusBadRequest, "INVALID_ARGUMENT"},
+	codes.OutOfRange:         {http.StatusBadRequest, "OUT_OF_RANGE"},
+	codes.Unauthenticated:    {http.StatusUnauthorized, "UNAUTHENTICATED"},
+	codes.PermissionDenied:   {http.StatusForbidden, "PERMISSION_DENIED"},
+	codes.NotFound:           {http.StatusNotFound, "NOT_FOUND"},
+	codes.AlreadyExists:      {http.StatusConflict, "ALREADY_EXISTS"},
+	codes.Aborted:            {http.StatusConflict, "ABORTED"},
+	codes.FailedPrecondition: {http.StatusPreconditionFailed, "FAILED_PRECONDITION"},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, "RESOURCE_EXHAUSTED"},
+	codes.Unimplemented:      {http.StatusNotImplemented, "UNIMPLEMENTED"},
+	codes.Unavailable:        {http.StatusServiceUnavailable, "UNAVAILABLE"},
+	codes.DeadlineExceeded:   {http.StatusServiceUnavailable, "UNAVAILABLE"},
+}
+
+// respondGRPCError is the single place gRPC errors from backend services are
+// turned into HTTP responses. Messages of client errors are passed through;
+// for server errors fallbackMessage is used so internals do not leak.
+func respondGRPCError(c *gin.Context, err error, fallbackMessage string) {
+	st := status.Convert(err)
+	mapped, ok := grpcErrorCodes[st.Code()]
+	if !ok {
+		respondError(c, http.StatusInternalServerError, "INTERNAL", fallbackMessage)
+		return
+	}
+
+	message := st.Message()
+	if mapped.httpStatus >= http.StatusInternalServerError {
+		message = fallbackMessage
+	}
+	c.JSON(mapped.httpStatus, ErrorResponse{
+		Error:     message,
+		Code:      mapped.code,
+		RequestId: requestId(c),
+		Details:   errorDetails(st),
+	})
+}
+
+func errorDetails(st *status.Status) []ErrorDetail {
+	var details []ErrorDetail
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, violation := range d.FieldViolations {
+				details = append(details, ErrorDetail{Field: violation.Field, Description: violation.Description})
+			}
+		case *errdetails.ErrorInfo:
+			details = append(details, ErrorDetail{Reason: d.Reason})
+		case *errdetails.ResourceInfo:
+			details = append(details, ErrorDetail{ResourceType: d.ResourceType, ResourceName: d.ResourceName})
+		}
+	}
+	return details
+}
@@ -1,11 +1,9 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"os"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +11,8 @@ import (
 
 func main() {
 	router := gin.Default()
+	router.Use(requestIdMiddleware())
+	router.Use(expandMiddleware())
 
 	passportServiceURL := os.Getenv("PASSPORT_URL")
 	if passportServiceURL == "" {
@@ -20,100 +20,130 @@ func main() {
 		os.Exit(1)
 	}
 
-	router.POST("/passport/register", func(c *gin.Context) {
-		proxyRequest(c, passportServiceURL+"/register", false)
-	})
+	// Tokens are verified locally with passport's public keys; passport is
+	// only polled for revoked sessions in the background.
+	denylist = NewPolledDenylist(passportServiceURL)
+	go denylist.Run(revocationPollInterval)
+	verifier = &TokenVerifier{Keys: NewKeySet(passportServiceURL), Revocation: denylist}
 
-	router.POST("/passport/login", func(c *gin.Context) {
-		proxyRequest(c, passportServiceURL+"/login", false)
+	router.GET("/passport/.well-known/jwks.json", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/.well-known/jwks.json", false)
+	})
+	router.POST("/passport/refresh", func(c *gin.Context) {
+		proxyRevokingRequest(c, passportServiceURL+"/refresh", false)
+	})
+	router.POST("/passport/logout", func(c *gin.Context) {
+		proxyRevokingRequest(c, passportServiceURL+"/logout", true)
+	})
+	router.GET("/passport/sessions", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/sessions", true)
+	})
+	router.DELETE("/passport/sessions/:id", func(c *gin.Context) {
+		proxyRevokingRequest(c, passportServiceURL+"/sessions/"+url.PathEscape(c.Param("id")), true)
+	})
+	router.POST("/passport/users/:id/follow", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/users/"+url.PathEscape(c.Param("id"))+"/follow", true)
+	})
+	router.DELETE("/passport/users/:id/follow", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/users/"+url.PathEscape(c.Param("id"))+"/follow", true)
+	})
+	router.GET("/passport/users/:id/followers", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/users/"+url.PathEscape(c.Param("id"))+"/followers", true)
 	})
-	router.GET("/passport/me", func(c *gin.Context) {
-		proxyRequest(c, passportServiceURL+"/me", true)
+	router.GET("/passport/users/:id/following", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/users/"+url.PathEscape(c.Param("id"))+"/following", true)
 	})
-	router.PUT("/passport/me", func(c *gin.Context) {
-		proxyRequest(c, passportServiceURL+"/me", true)
+	router.GET("/passport/users/:id/following/:target_id", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/users/"+url.PathEscape(c.Param("id"))+"/following/"+url.PathEscape(c.Param("target_id")), true)
+	})
+	router.GET("/passport/users/:id/follow_counts", func(c *gin.Context) {
+		proxyRequest(c, passportServiceURL+"/users/"+url.PathEscape(c.Param("id"))+"/follow_counts", true)
 	})
 
+	setupPassportRoutes(router)
+	setupStatistics()
+	setupMedia()
 	setupPostsRoutes(router)
 
 	router.Run(fmt.Sprintf("0.0.0.0:%s", os.Getenv("PORT")))
 }
 
-func CheckToken(token string) (user_id string, err error) {
-	body, err := json.Marshal(map[string]any{"Token": token})
-	if err != nil {
-		fmt.Printf("Failed to marshal JSON: %v\n", err)
-		return "", err
-	}
+// verifier checks access tokens for every authenticated route. It is set up
+// in main before the router starts.
+var verifier *TokenVerifier
 
-	req, err := http.NewRequest("GET", os.Getenv("PASSPORT_URL")+"/check_token", bytes.NewBuffer(body))
-	if err != nil {
-		fmt.Printf("Failed to create request: %v\n", err)
-		return "", err
-	}
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		fmt.Printf("Failed to send request: %v\n", err)
-		return
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		fmt.Printf("Failed to check token: %v\n", resp.Status)
-		return "", fmt.Errorf("failed to check token: %v", resp.Status)
-	}
-	body, err = io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Printf("Failed to read response body: %v\n", err)
-		return
-	}
-	var response map[string]any = make(map[string]any)
-	err = json.Unmarshal(body, &response)
+// denylist is the revocation check used by verifier.
+var denylist *PolledDenylist
+
+func CheckToken(token string) (user_id string, session_id string, err error) {
+	user_id, session_id, err = verifier.Verify(token)
 	if err != nil {
-		fmt.Printf("Failed to unmarshal JSON: %v\n", err)
-		return
+		return "", "", err
 	}
-	user_id = response["user_id"].(string)
-	fmt.Printf("check User ID: %s\n", user_id)
+	fmt.Printf("check User ID: %s, Session ID: %s\n", user_id, session_id)
+	return user_id, session_id, nil
+}
 
-	return user_id, nil
+// proxyRevokingRequest proxies a request that may revoke sessions and syncs
+// the denylist before answering, so the caller's next request already sees
+// the revocation.
+func proxyRevokingRequest(c *gin.Context, url string, authRequired bool) {
+	proxyRequestThen(c, url, authRequired, func() {
+		if err := denylist.Sync(); err != nil {
+			fmt.Printf("Failed to sync revoked sessions: %v\n", err)
+		}
+	})
 }
 
 func proxyRequest(c *gin.Context, url string, authRequired bool) {
+	proxyRequestThen(c, url, authRequired, nil)
+}
+
+// proxyRequestThen proxies the request and calls beforeResponse, if set,
+// once passport has answered but before the answer is sent on.
+func proxyRequestThen(c *gin.Context, url string, authRequired bool, beforeResponse func()) {
+	if c.Request.URL.RawQuery != "" {
+		url += "?" + c.Request.URL.RawQuery
+	}
 	req, err := http.NewRequest(c.Request.Method, url, c.Request.Body)
 	if err != nil {
 		fmt.Printf("Failed to create request: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create request"})
+		respondError(c, http.StatusInternalServerError, "INTERNAL", "Failed to create request")
 		return
 	}
 
 	req.Header = c.Request.Header
+	req.Header.Set("X-Forwarded-For", c.ClientIP())
+	req.Header.Set(requestIdHeader, requestId(c))
 	if authRequired {
 		token := c.Request.Header.Get("Authorization")
 		if token == "" {
 			fmt.Println("Authorization token is required")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization token is required"})
+			respondError(c, http.StatusUnauthorized, "UNAUTHENTICATED", "Authorization token is required")
 			return
 		}
-		user_id, err := CheckToken(token)
+		user_id, session_id, err := CheckToken(token)
 		if err != nil {
 			fmt.Printf("Failed to check token: %v\n", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to check token"})
+			respondError(c, http.StatusUnauthorized, "UNAUTHENTICATED", "Failed to check token")
 			return
 		}
 		req.Header.Set("X-User-Id", user_id)
+		req.Header.Set("X-Session-Id", session_id)
 
 		req.Header.Del("Authorization")
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		fmt.Printf("Failed to proxy request: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to proxy request"})
+		respondError(c, http.StatusServiceUnavailable, "UNAVAILABLE", "Failed to proxy request")
 		return
 	}
 	defer resp.Body.Close()
 
+	if beforeResponse != nil {
+		beforeResponse()
+	}
 	c.DataFromReader(resp.StatusCode, resp.ContentLength, resp.Header.Get("Content-Type"), resp.Body, nil)
 }
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxUploadSize = 20 << 20
+	// thumbnailSize bounds the width and height of thumbnails.
+	thumbnailSize = 320
+	// maxImagePixels keeps huge images, which would take too much memory to
+	// decode, from getting a thumbnail.
+	maxImagePixels = 40_000_000
+
+	defaultBlobCollectInterval = time.Hour
+)
+
+// allowedMimeTypes are the sniffed content types attachments may have.
+var allowedMimeTypes = map[string]bool{
+	"image/jpeg":                true,
+	"image/png":                 true,
+	"image/gif":                 true,
+	"image/webp":                true,
+	"application/pdf":           true,
+	"text/plain; charset=utf-8": true,
+}
+
+// maxUploadSize is the largest attachment in bytes, from MAX_UPLOAD_SIZE.
+var maxUploadSize int64 = defaultMaxUploadSize
+
+// blobCollectInterval is how often unreferenced blobs are deleted, from
+// BLOB_COLLECT_INTERVAL.
+var blobCollectInterval = defaultBlobCollectInterval
+
+func setupMedia() {
+	if value := os.Getenv("MAX_UPLOAD_SIZE"); value != "" {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || size <= 0 {
+			fmt.Printf("MAX_UPLOAD_SIZE must be a positive number of bytes, got %q\n", value)
+			os.Exit(1)
+		}
+		maxUploadSize = size
+	}
+	if value := os.Getenv("BLOB_COLLECT_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			fmt.Printf("BLOB_COLLECT_INTERVAL must be a positive duration such as 1h, got %q\n", value)
+			os.Exit(1)
+		}
+		blobCollectInterval = interval
+	}
+	blobs = newBlobStore()
+}
+
+// sniffContentType detects the type of the content from its first bytes;
+// the type the client claims is never trusted.
+func sniffContentType(content io.ReaderAt) (string, error) {
+	head := make([]byte, 512)
+	n, err := content.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+// imageMedia describes an image attachment: its dimensions and, if one could
+// be made, an encoded thumbnail.
+type imageMedia struct {
+	Width, Height int
+	Thumbnail     []byte
+}
+
+// readImage reads the dimensions of a JPEG, PNG or GIF image and makes its
+// thumbnail. Other images, such as WebP, are reported as not decodable.
+func readImage(content io.ReadSeeker, mimeType string) (*imageMedia, error) {
+	config, _, err := image.DecodeConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	media := &imageMedia{Width: config.Width, Height: config.Height}
+	if config.Width*config.Height > maxImagePixels {
+		return media, nil
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(content)
+	if err != nil {
+		return nil, err
+	}
+	thumbnail := scaleDown(img, thumbnailSize)
+	var encoded bytes.Buffer
+	if mimeType == "image/jpeg" {
+		err = jpeg.Encode(&encoded, thumbnail, &jpeg.Options{Quality: 80})
+	} else {
+		err = png.Encode(&encoded, thumbnail)
+	}
+	if err != nil {
+		return nil, err
+	}
+	media.Thumbnail = encoded.Bytes()
+	return media, nil
+}
+
+// thumbnailContentType is the type readImage encodes thumbnails of images of
+// the given type in.
+func thumbnailContentType(mimeType string) string {
+	if mimeType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// scaleDown shrinks img to fit into a size×size square keeping its aspect
+// ratio. Every pixel of the result is the average of the pixels it covers,
+// which keeps thin lines and text readable. Smaller images are only copied.
+func scaleDown(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	scaledWidth, scaledHeight := width, height
+	if width > size || height > size {
+		if width >= height {
+			scaledWidth, scaledHeight = size, max(1, height*size/width)
+		} else {
+			scaledWidth, scaledHeight = max(1, width*size/height), size
+		}
+	}
+
+	scaled := image.NewNRGBA(image.Rect(0, 0, scaledWidth, scaledHeight))
+	for y := 0; y < scaledHeight; y++ {
+		y0, y1 := bounds.Min.Y+y*height/scaledHeight, bounds.Min.Y+(y+1)*height/scaledHeight
+		for x := 0; x < scaledWidth; x++ {
+			x0, x1 := bounds.Min.X+x*width/scaledWidth, bounds.Min.X+(x+1)*width/scaledWidth
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			// Colors are premultiplied by alpha, so averaging them is exact.
+			scaled.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"msg.i3cheese.ru/proto/passport"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+type RegisterRequest struct {
+	Login       string `json:"login"`
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	Name        string `json:"name"`
+	Surname     string `json:"surname"`
+	DateOfBirth string `json:"date_of_birth"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+type LoginRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int32  `json:"expires_in"`
+	UserId       string `json:"user_id"`
+	SessionId    string `json:"session_id"`
+}
+
+type UserInfo struct {
+	Login       string `json:"login"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Surname     string `json:"surname"`
+	DateOfBirth string `json:"date_of_birth"`
+	PhoneNumber string `json:"phone_number"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url"`
+	Bio         string `json:"bio"`
+}
+
+type UpdateUserRequest struct {
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Surname     string `json:"surname"`
+	DateOfBirth string `json:"date_of_birth"`
+	PhoneNumber string `json:"phone_number"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url"`
+	Bio         string `json:"bio"`
+}
+
+// Profile is the public part of a user, shown to anyone.
+type Profile struct {
+	UserId         string    `json:"user_id"`
+	Login          string    `json:"login"`
+	DisplayName    string    `json:"display_name"`
+	AvatarUrl      string    `json:"avatar_url"`
+	Bio            string    `json:"bio"`
+	FollowersCount int32     `json:"followers_count"`
+	FollowingCount int32     `json:"following_count"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func profileFromProto(profile *passport.Profile) Profile {
+	return Profile{
+		UserId:         profile.UserId,
+		Login:          profile.Login,
+		DisplayName:    profile.DisplayName,
+		AvatarUrl:      profile.AvatarUrl,
+		Bio:            profile.Bio,
+		FollowersCount: profile.FollowersCount,
+		FollowingCount: profile.FollowingCount,
+		CreatedAt:      profile.CreatedAt.AsTime(),
+	}
+}
+
+// setupPassportRoutes registers the routes served through passport's gRPC
+// API. The connection is shared by all requests.
+func setupPassportRoutes(router *gin.Engine) {
+	passportGRPCURL := os.Getenv("PASSPORT_GRPC_URL")
+	if passportGRPCURL == "" {
+		fmt.Println("PASSPORT_GRPC_URL is required")
+		os.Exit(1)
+	}
+	conn, err := grpc.NewClient(passportGRPCURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		fmt.Printf("Failed to create passport client: %v\n", err)
+		os.Exit(1)
+	}
+	client := passport.NewPassportServiceClient(conn)
+	authorCache = NewAuthorCache(client, authorCacheTTL)
+
+	router.POST("/passport/register", func(c *gin.Context) {
+		handleRegister(c, client)
+	})
+	router.POST("/passport/login", func(c *gin.Context) {
+		handleLogin(c, client)
+	})
+	router.GET("/passport/me", func(c *gin.Context) {
+		handleGetMyInfo(c, client)
+	})
+	router.PUT("/passport/me", func(c *gin.Context) {
+		handleUpdateMyInfo(c, client)
+	})
+	router.GET("/passport/users/search", func(c *gin.Context) {
+		handleSearchUsers(c, client)
+	})
+	router.GET("/passport/users/by-login/:login", func(c *gin.Context) {
+		handleGetProfile(c, client, &passport.GetProfileRequest{User: &passport.GetProfileRequest_Login{Login: c.Param("login")}})
+	})
+	router.GET("/passport/users/:id", func(c *gin.Context) {
+		handleGetProfile(c, client, &passport.GetProfileRequest{User: &passport.GetProfileRequest_UserId{UserId: c.Param("id")}})
+	})
+}
+
+// passportContext returns the context for a call to passport, carrying the
+// request id so both sides log the same one.
+func passportContext(c *gin.Context, pairs ...string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	md := metadata.Pairs(append(pairs, "request_id", requestId(c))...)
+	return metadata.NewOutgoingContext(ctx, md), cancel
+}
+
+func handleRegister(c *gin.Context, client passport.PassportServiceClient) {
+	var req RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	ctx, cancel := passportContext(c)
+	defer cancel()
+
+	_, err := client.Register(ctx, &passport.RegisterRequest{
+		Login:       req.Login,
+		Email:       req.Email,
+		Password:    req.Password,
+		Name:        req.Name,
+		Surname:     req.Surname,
+		DateOfBirth: req.DateOfBirth,
+		PhoneNumber: req.PhoneNumber,
+	})
+	if err != nil {
+		fmt.Printf("Failed to register user: %v\n", err)
+		respondGRPCError(c, err, "Failed to register user")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "User registered successfully"})
+}
+
+func handleLogin(c *gin.Context, client passport.PassportServiceClient) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	ctx, cancel := passportContext(c)
+	defer cancel()
+
+	resp, err := client.Login(ctx, &passport.LoginRequest{
+		Login:     req.Login,
+		Password:  req.Password,
+		UserAgent: c.Request.UserAgent(),
+		Ip:        c.ClientIP(),
+	})
+	if err != nil {
+		fmt.Printf("Failed to log in: %v\n", err)
+		respondGRPCError(c, err, "Failed to log in")
+		return
+	}
+	c.JSON(http.StatusOK, LoginResponse{
+		Token:        resp.Token,
+		RefreshToken: resp.RefreshToken,
+		ExpiresIn:    resp.ExpiresIn,
+		UserId:       resp.UserId,
+		SessionId:    resp.SessionId,
+	})
+}
+
+func handleGetMyInfo(c *gin.Context, client passport.PassportServiceClient) {
+	user_id, _, err := CheckToken(c.Request.Header.Get("Authorization"))
+	if err != nil {
+		fmt.Printf("Failed to check token: %v\n", err)
+		respondError(c, http.StatusUnauthorized, "UNAUTHENTICATED", "Unauthorized")
+		return
+	}
+	ctx, cancel := passportContext(c)
+	defer cancel()
+
+	resp, err := client.GetUser(ctx, &passport.GetUserRequest{UserId: user_id})
+	if err != nil {
+		fmt.Printf("Failed to get user: %v\n", err)
+		respondGRPCError(c, err, "Failed to get user")
+		return
+	}
+	c.JSON(http.StatusOK, UserInfo{
+		Login:       resp.User.Login,
+		Email:       resp.User.Email,
+		Name:        resp.User.Name,
+		Surname:     resp.User.Surname,
+		DateOfBirth: resp.User.DateOfBirth,
+		PhoneNumber: resp.User.PhoneNumber,
+		DisplayName: resp.User.DisplayName,
+		AvatarUrl:   resp.User.AvatarUrl,
+		Bio:         resp.User.Bio,
+	})
+}
+
+func handleUpdateMyInfo(c *gin.Context, client passport.PassportServiceClient) {
+	user_id, _, err := CheckToken(c.Request.Header.Get("Authorization"))
+	if err != nil {
+		fmt.Printf("Failed to check token: %v\n", err)
+		respondError(c, http.StatusUnauthorized, "UNAUTHENTICATED", "Unauthorized")
+		return
+	}
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	ctx, cancel := passportContext(c, "actor_user_id", user_id)
+	defer cancel()
+
+	_, err = client.UpdateUser(ctx, &passport.UpdateUserRequest{
+		Email:       req.Email,
+		Name:        req.Name,
+		Surname:     req.Surname,
+		DateOfBirth: req.DateOfBirth,
+		PhoneNumber: req.PhoneNumber,
+		DisplayName: req.DisplayName,
+		AvatarUrl:   req.AvatarUrl,
+		Bio:         req.Bio,
+	})
+	if err != nil {
+		fmt.Printf("Failed to update user: %v\n", err)
+		respondGRPCError(c, err, "Failed to update user")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "User updated successfully"})
+}
+
+func handleGetProfile(c *gin.Context, client passport.PassportServiceClient, req *passport.GetProfileRequest) {
+	if _, _, err := CheckToken(c.Request.Header.Get("Authorization")); err != nil {
+		fmt.Printf("Failed to check token: %v\n", err)
+		respondError(c, http.StatusUnauthorized, "UNAUTHENTICATED", "Unauthorized")
+		return
+	}
+	ctx, cancel := passportContext(c)
+	defer cancel()
+
+	resp, err := client.GetProfile(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to get profile: %v\n", err)
+		respondGRPCError(c, err, "Failed to get profile")
+		return
+	}
+	c.JSON(http.StatusOK, profileFromProto(resp.Profile))
+}
+
+func handleSearchUsers(c *gin.Context, client passport.PassportServiceClient) {
+	if _, _, err := CheckToken(c.Request.Header.Get("Authorization")); err != nil {
+		fmt.Printf("Failed to check token: %v\n", err)
+		respondError(c, http.StatusUnauthorized, "UNAUTHENTICATED", "Unauthorized")
+		return
+	}
+	req := &passport.SearchUsersRequest{Query: c.Query("q")}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+	ctx, cancel := passportContext(c)
+	defer cancel()
+
+	resp, err := client.SearchUsers(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to search users: %v\n", err)
+		respondGRPCError(c, err, "Failed to search users")
+		return
+	}
+	users := make([]Profile, 0, len(resp.Profiles))
+	for _, profile := range resp.Profiles {
+		users = append(users, profileFromProto(profile))
+	}
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"msg.i3cheese.ru/proto/posts" // Import the generated gRPC package
@@ -14,6 +17,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -33,46 +37,200 @@ func setupPostsRoutes(router *gin.Engine) {
 	router.PUT("/posts/:id", func(c *gin.Context) {
 		handleUpdatePost(c, postsServiceURL)
 	})
+	router.PATCH("/posts/:id", func(c *gin.Context) {
+		handlePatchPost(c, postsServiceURL)
+	})
+	router.GET("/posts/search", func(c *gin.Context) {
+		handleSearchPosts(c, postsServiceURL)
+	})
+	router.GET("/posts/trending", func(c *gin.Context) {
+		handleGetTrending(c, postsServiceURL)
+	})
+	router.GET("/posts/trash", func(c *gin.Context) {
+		handleListDeletedPosts(c, postsServiceURL)
+	})
+	router.POST("/posts/:id/restore", func(c *gin.Context) {
+		handleRestorePost(c, postsServiceURL)
+	})
+	router.GET("/posts/drafts", func(c *gin.Context) {
+		handleListMyDrafts(c, postsServiceURL)
+	})
+	router.POST("/posts/:id/publish", func(c *gin.Context) {
+		handlePublishPost(c, postsServiceURL)
+	})
+	router.POST("/attachments", func(c *gin.Context) {
+		handleUploadAttachment(c, postsServiceURL)
+	})
+	router.GET("/attachments/:id", func(c *gin.Context) {
+		handleGetAttachment(c, postsServiceURL)
+	})
+	router.GET("/attachments/:id/thumbnail", func(c *gin.Context) {
+		handleGetAttachmentThumbnail(c, postsServiceURL)
+	})
+	router.POST("/uploads", func(c *gin.Context) {
+		handleCreateUpload(c, postsServiceURL)
+	})
+	router.GET("/uploads/:id", func(c *gin.Context) {
+		handleGetUpload(c, postsServiceURL)
+	})
+	router.PUT("/uploads/:id", func(c *gin.Context) {
+		handleUploadChunk(c, postsServiceURL)
+	})
 	router.GET("/posts/:id", func(c *gin.Context) {
 		handleGetPostById(c, postsServiceURL)
 	})
 	router.GET("/posts", func(c *gin.Context) {
 		handleGetPosts(c, postsServiceURL)
 	})
+	router.GET("/tags/popular", func(c *gin.Context) {
+		handleListPopularTags(c, postsServiceURL)
+	})
+	router.GET("/feed", func(c *gin.Context) {
+		handleGetFeed(c, postsServiceURL)
+	})
+
+	router.GET("/posts/:id/revisions", func(c *gin.Context) {
+		handleListPostRevisions(c, postsServiceURL)
+	})
+	router.GET("/posts/:id/revisions/:revision", func(c *gin.Context) {
+		handleGetPostRevision(c, postsServiceURL)
+	})
+	router.POST("/posts/:id/revisions/:revision/restore", func(c *gin.Context) {
+		handleRestorePostRevision(c, postsServiceURL)
+	})
+
+	router.POST("/posts/:id/comments", func(c *gin.Context) {
+		handleCreateComment(c, postsServiceURL)
+	})
+	router.GET("/posts/:id/comments", func(c *gin.Context) {
+		handleListComments(c, postsServiceURL)
+	})
+	router.PUT("/posts/:id/comments/:comment_id", func(c *gin.Context) {
+		handleUpdateComment(c, postsServiceURL)
+	})
+	router.DELETE("/posts/:id/comments/:comment_id", func(c *gin.Context) {
+		handleDeleteComment(c, postsServiceURL)
+	})
+
+	router.PUT("/posts/:id/reactions", func(c *gin.Context) {
+		handleSetReaction(c, postsServiceURL)
+	})
+	router.DELETE("/posts/:id/reactions", func(c *gin.Context) {
+		handleRemoveReaction(c, postsServiceURL)
+	})
+	router.GET("/posts/:id/reactions", func(c *gin.Context) {
+		handleListReactions(c, postsServiceURL)
+	})
+
+	// Blobs are removed here, since only the posts service knows which ones
+	// are still in use.
+	collector := &BlobCollector{PostsServiceURL: postsServiceURL, Interval: blobCollectInterval}
+	go collector.Run()
 }
 
 type CreatePostRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	IsPrivate   bool   `json:"is_private"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	IsPrivate   bool     `json:"is_private"`
+	Tags        []string `json:"tags"`
+	// Status, PublishAt and AttachmentIds are only read on creation; drafts
+	// are published with POST /posts/:id/publish and attachments changed
+	// with PATCH.
+	Status        string     `json:"status"`
+	PublishAt     *time.Time `json:"publish_at"`
+	AttachmentIds []string   `json:"attachment_ids"`
 }
 
 type Post struct {
-	PostId      string    `json:"post_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	CreatorId   string    `json:"creator_id"`
-	IsPrivate   bool      `json:"is_private"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	PostId         string           `json:"post_id"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	CreatorId      string           `json:"creator_id"`
+	IsPrivate      bool             `json:"is_private"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	ReactionCounts map[string]int32 `json:"reaction_counts"`
+	MyReaction     string           `json:"my_reaction,omitempty"`
+	Tags           []string         `json:"tags"`
+	Revision       int32            `json:"revision"`
+	Edited         bool             `json:"edited"`
+	Status         string           `json:"status"`
+	// Missing for drafts.
+	PublishAt   *time.Time   `json:"publish_at,omitempty"`
+	Attachments []Attachment `json:"attachments"`
+	// Missing when the statistics service is unavailable.
+	ViewCount *int64 `json:"view_count,omitempty"`
+	// Only set with ?expand=author, and missing if passport is unavailable.
+	Author *Author `json:"author,omitempty"`
+}
+
+type SearchResult struct {
+	Post               Post    `json:"post"`
+	Rank               float32 `json:"rank"`
+	TitleHighlight     string  `json:"title_highlight"`
+	DescriptionSnippet string  `json:"description_snippet"`
+}
+
+func postFromProto(post *posts.Post) Post {
+	reactionCounts := post.ReactionCounts
+	if reactionCounts == nil {
+		reactionCounts = map[string]int32{}
+	}
+	tags := post.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	attachments := make([]Attachment, 0, len(post.Attachments))
+	for _, attachment := range post.Attachments {
+		attachments = append(attachments, attachmentFromProto(attachment))
+	}
+	var publishAt *time.Time
+	if post.PublishAt != nil {
+		at := post.PublishAt.AsTime()
+		publishAt = &at
+	}
+	return Post{
+		PostId:         post.PostId,
+		Title:          post.Title,
+		Description:    post.Description,
+		CreatorId:      post.CreatorId,
+		IsPrivate:      post.IsPrivate,
+		CreatedAt:      post.CreatedAt.AsTime(),
+		UpdatedAt:      post.UpdatedAt.AsTime(),
+		ReactionCounts: reactionCounts,
+		MyReaction:     post.MyReaction,
+		Tags:           tags,
+		Revision:       post.Revision,
+		Edited:         post.Edited,
+		Status:         postStatusNames[post.Status],
+		PublishAt:      publishAt,
+		Attachments:    attachments,
+	}
 }
 
 // Retunrn client, context, func to defer and close the connection
 // and error if any
 func prepareRequest(c *gin.Context, postsServiceURL string) (posts.PostServiceClient, context.Context, func(), error) {
-	user_id, err := CheckToken(c.Request.Header.Get("Authorization"))
+	return prepareRequestWithTimeout(c, postsServiceURL, 5*time.Second)
+}
+
+// prepareRequestWithTimeout is prepareRequest for handlers that also move a
+// lot of data, such as uploads, and need longer than the usual 5 seconds.
+func prepareRequestWithTimeout(c *gin.Context, postsServiceURL string, timeout time.Duration) (posts.PostServiceClient, context.Context, func(), error) {
+	user_id, _, err := CheckToken(c.Request.Header.Get("Authorization"))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		respondError(c, http.StatusUnauthorized, "UNAUTHENTICATED", "Unauthorized")
 		return nil, nil, nil, err
 	}
+	c.Set("user_id", user_id)
 	conn, err := grpc.NewClient(postsServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to connect to posts service"})
+		respondError(c, http.StatusInternalServerError, "INTERNAL", "Failed to connect to posts service")
 		return nil, nil, nil, err
 	}
 	client := posts.NewPostServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	md := metadata.Pairs("actor_user_id", user_id)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	md := metadata.Pairs("actor_user_id", user_id, "request_id", requestId(c))
 	ctx = metadata.NewOutgoingContext(ctx, md)
 	return client, ctx, func() {
 		cancel()
@@ -88,33 +246,37 @@ func handleCreatePost(c *gin.Context, postsServiceURL string) {
 	var req CreatePostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		fmt.Printf("Failed to bind JSON: %v\n", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
 		return
 	}
 	defer closeConn()
+	status, ok := postStatuses[req.Status]
+	if !ok {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid status, expected published, draft or scheduled")
+		return
+	}
 	createPostRequest := &posts.CreatePostRequest{
-		Title:       req.Title,
-		Description: req.Description,
-		IsPrivate:   req.IsPrivate,
+		Title:         req.Title,
+		Description:   req.Description,
+		IsPrivate:     req.IsPrivate,
+		Tags:          req.Tags,
+		Status:        status,
+		AttachmentIds: req.AttachmentIds,
+	}
+	if req.PublishAt != nil {
+		createPostRequest.PublishAt = timestamppb.New(*req.PublishAt)
 	}
 	fmt.Printf("ctx User ID: %s\n", ctx.Value("actor_user_id"))
 	resp, err := client.CreatePost(ctx, createPostRequest)
 	if err != nil {
 		fmt.Printf("Failed to create post: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create post"})
+		respondGRPCError(c, err, "Failed to create post")
 		return
 	}
-	// parse the response into the Post struct
-	post := Post{
-		PostId:      resp.Post.PostId,
-		Title:       resp.Post.Title,
-		Description: resp.Post.Description,
-		CreatorId:   resp.Post.CreatorId,
-		IsPrivate:   resp.Post.IsPrivate,
-		CreatedAt:   resp.Post.CreatedAt.AsTime(),
-		UpdatedAt:   resp.Post.UpdatedAt.AsTime(),
-	}
-	c.JSON(http.StatusCreated, post)
+	post := []Post{postFromProto(resp.Post)}
+	fillPostAuthors(c, post)
+	c.Header("ETag", postETag(post[0].UpdatedAt))
+	c.JSON(http.StatusCreated, post[0])
 }
 
 func handleDeletePost(c *gin.Context, postsServiceURL string) {
@@ -129,12 +291,40 @@ func handleDeletePost(c *gin.Context, postsServiceURL string) {
 	_, err = client.DeletePost(ctx, req)
 	if err != nil {
 		fmt.Printf("Failed to delete post: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete post"})
+		respondGRPCError(c, err, "Failed to delete post")
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"success": true})
 }
 
+// patchablePostFields are the JSON fields PATCH /posts/:id accepts, in the
+// order they are put into the update mask.
+var patchablePostFields = []string{"title", "description", "is_private", "tags", "attachment_ids"}
+
+// postETag identifies the version of a post by its update time in
+// microseconds, the precision the posts database keeps.
+func postETag(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixMicro(), 10) + `"`
+}
+
+// ifMatchUpdatedAt turns the If-Match header into the update time the post is
+// expected to have, nil if the header is missing or "*". An ETag that is not
+// ours can not match any version, so it is answered with 412 right away.
+func ifMatchUpdatedAt(c *gin.Context) (*timestamppb.Timestamp, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, true
+	}
+	version, opened := strings.CutPrefix(ifMatch, `"`)
+	version, closed := strings.CutSuffix(version, `"`)
+	micros, err := strconv.ParseInt(version, 10, 64)
+	if !opened || !closed || err != nil {
+		respondError(c, http.StatusPreconditionFailed, "FAILED_PRECONDITION", "If-Match does not match the post")
+		return nil, false
+	}
+	return timestamppb.New(time.UnixMicro(micros)), true
+}
+
 func handleUpdatePost(c *gin.Context, postsServiceURL string) {
 	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
 	if err != nil {
@@ -146,23 +336,95 @@ func handleUpdatePost(c *gin.Context, postsServiceURL string) {
 	var reqBody CreatePostRequest
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		fmt.Printf("Failed to bind JSON: %v\n", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	expectedUpdatedAt, ok := ifMatchUpdatedAt(c)
+	if !ok {
 		return
 	}
 
+	// PUT replaces the whole post, so the update mask stays empty.
 	req := &posts.UpdatePostRequest{
-		PostId:      postId,
-		Title:       reqBody.Title,
-		Description: reqBody.Description,
-		IsPrivate:   reqBody.IsPrivate,
+		PostId:            postId,
+		Title:             reqBody.Title,
+		Description:       reqBody.Description,
+		IsPrivate:         reqBody.IsPrivate,
+		Tags:              reqBody.Tags,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	}
+	respondUpdatedPost(c, client, ctx, req)
+}
+
+// handlePatchPost applies a JSON merge patch (RFC 7396) to a post: only the
+// fields present in the body change, and null resets a field.
+func handlePatchPost(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
 	}
+	defer closeConn()
+
+	var patch map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	for field := range patch {
+		if !slices.Contains(patchablePostFields, field) {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("Field %q can not be changed", field))
+			return
+		}
+	}
+	if len(patch) == 0 {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Nothing to update")
+		return
+	}
+	expectedUpdatedAt, ok := ifMatchUpdatedAt(c)
+	if !ok {
+		return
+	}
+
+	req := &posts.UpdatePostRequest{
+		PostId:            c.Param("id"),
+		UpdateMask:        &fieldmaskpb.FieldMask{},
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	}
+	targets := map[string]any{
+		"title":          &req.Title,
+		"description":    &req.Description,
+		"is_private":     &req.IsPrivate,
+		"tags":           &req.Tags,
+		"attachment_ids": &req.AttachmentIds,
+	}
+	for _, field := range patchablePostFields {
+		value, ok := patch[field]
+		if !ok {
+			continue
+		}
+		// Unmarshalling null leaves the zero value, which is what merge
+		// patch means by removing a field.
+		if err := json.Unmarshal(value, targets[field]); err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("Invalid value of %q", field))
+			return
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, field)
+	}
+	respondUpdatedPost(c, client, ctx, req)
+}
+
+func respondUpdatedPost(c *gin.Context, client posts.PostServiceClient, ctx context.Context, req *posts.UpdatePostRequest) {
 	resp, err := client.UpdatePost(ctx, req)
 	if err != nil {
 		fmt.Printf("Failed to update post: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update post"})
+		respondGRPCError(c, err, "Failed to update post")
 		return
 	}
-	c.JSON(http.StatusOK, resp.Post)
+	post := []Post{postFromProto(resp.Post)}
+	fillPostAuthors(c, post)
+	c.Header("ETag", postETag(post[0].UpdatedAt))
+	c.JSON(http.StatusOK, post[0])
 }
 
 func handleGetPostById(c *gin.Context, postsServiceURL string) {
@@ -177,10 +439,18 @@ func handleGetPostById(c *gin.Context, postsServiceURL string) {
 	resp, err := client.GetPostById(ctx, req)
 	if err != nil {
 		fmt.Printf("Failed to fetch post: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch post"})
+		respondGRPCError(c, err, "Failed to fetch post")
 		return
 	}
-	c.JSON(http.StatusOK, resp.Post)
+	if viewRecorder != nil {
+		viewRecorder.Record(c.GetString("user_id"), postId, c.Request.UserAgent(), c.ClientIP())
+	}
+
+	post := []Post{postFromProto(resp.Post)}
+	fillViewCounts(c, post)
+	fillPostAuthors(c, post)
+	c.Header("ETag", postETag(post[0].UpdatedAt))
+	c.JSON(http.StatusOK, post[0])
 }
 
 func handleGetPosts(c *gin.Context, postsServiceURL string) {
@@ -199,29 +469,191 @@ func handleGetPosts(c *gin.Context, postsServiceURL string) {
 	if startFrom != "" {
 		parsedTime, err := time.Parse(time.RFC3339, startFrom)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_from format"})
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid start_from format")
 			return
 		}
 		req.StartFrom = timestamppb.New(parsedTime)
 	}
 	parsedLimit, err := strconv.Atoi(limit)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit format"})
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
 		return
 	}
 	req.Limit = int32(parsedLimit)
+	req.PageToken = c.Query("page_token")
+	if tags := c.Query("tags"); tags != "" {
+		req.Tags = strings.Split(tags, ",")
+	}
+	switch c.DefaultQuery("tag_match", "any") {
+	case "any":
+		req.TagMatch = posts.TagMatch_TAG_MATCH_ANY
+	case "all":
+		req.TagMatch = posts.TagMatch_TAG_MATCH_ALL
+	default:
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid tag_match, expected any or all")
+		return
+	}
 
 	resp, err := client.GetPosts(ctx, req)
 	if err != nil {
 		fmt.Printf("Failed to fetch posts: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch posts"})
+		respondGRPCError(c, err, "Failed to fetch posts")
 		return
 	}
 
-	// Ensure posts is an empty array if nil
-	if resp.Posts == nil {
-		resp.Posts = []*posts.Post{}
+	postsList := make([]Post, 0, len(resp.Posts))
+	for _, post := range resp.Posts {
+		postsList = append(postsList, postFromProto(post))
+	}
+	fillViewCounts(c, postsList)
+	fillPostAuthors(c, postsList)
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts":           postsList,
+		"total_count":     resp.TotalCount,
+		"next_page_token": resp.NextPageToken,
+		"prev_page_token": resp.PrevPageToken,
+		"has_more":        resp.HasMore,
+	})
+}
+
+func handleGetFeed(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
 	}
+	defer closeConn()
 
-	c.JSON(http.StatusOK, gin.H{"posts": resp.Posts, "total_count": resp.TotalCount})
+	req := &posts.GetFeedRequest{Cursor: c.Query("cursor")}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.GetFeed(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch feed: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch feed")
+		return
+	}
+
+	postsList := make([]Post, 0, len(resp.Posts))
+	for _, post := range resp.Posts {
+		postsList = append(postsList, postFromProto(post))
+	}
+	fillViewCounts(c, postsList)
+	fillPostAuthors(c, postsList)
+	c.JSON(http.StatusOK, gin.H{"posts": postsList, "next_cursor": resp.NextCursor})
+}
+
+func handleGetTrending(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.GetTrendingRequest{}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.GetTrending(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch trending posts: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch trending posts")
+		return
+	}
+
+	postsList := make([]Post, 0, len(resp.Posts))
+	for _, post := range resp.Posts {
+		postsList = append(postsList, postFromProto(post))
+	}
+	fillViewCounts(c, postsList)
+	fillPostAuthors(c, postsList)
+	c.JSON(http.StatusOK, gin.H{"posts": postsList})
+}
+
+func handleSearchPosts(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.SearchPostsRequest{
+		Query:     c.Query("q"),
+		CreatorId: c.Query("creator_id"),
+		Cursor:    c.Query("cursor"),
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+	if tags := c.Query("tags"); tags != "" {
+		req.Tags = strings.Split(tags, ",")
+	}
+	switch c.DefaultQuery("tag_match", "any") {
+	case "any":
+		req.TagMatch = posts.TagMatch_TAG_MATCH_ANY
+	case "all":
+		req.TagMatch = posts.TagMatch_TAG_MATCH_ALL
+	default:
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid tag_match, expected any or all")
+		return
+	}
+	if createdAfter := c.Query("created_after"); createdAfter != "" {
+		parsedTime, err := time.Parse(time.RFC3339, createdAfter)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid created_after format")
+			return
+		}
+		req.CreatedAfter = timestamppb.New(parsedTime)
+	}
+	if createdBefore := c.Query("created_before"); createdBefore != "" {
+		parsedTime, err := time.Parse(time.RFC3339, createdBefore)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid created_before format")
+			return
+		}
+		req.CreatedBefore = timestamppb.New(parsedTime)
+	}
+
+	resp, err := client.SearchPosts(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to search posts: %v\n", err)
+		respondGRPCError(c, err, "Failed to search posts")
+		return
+	}
+
+	postsList := make([]Post, 0, len(resp.Results))
+	for _, result := range resp.Results {
+		postsList = append(postsList, postFromProto(result.Post))
+	}
+	fillViewCounts(c, postsList)
+	fillPostAuthors(c, postsList)
+
+	results := make([]SearchResult, 0, len(resp.Results))
+	for i, result := range resp.Results {
+		results = append(results, SearchResult{
+			Post:               postsList[i],
+			Rank:               result.Rank,
+			TitleHighlight:     result.TitleHighlight,
+			DescriptionSnippet: result.DescriptionSnippet,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"results": results, "next_cursor": resp.NextCursor})
 }
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+type SetReactionRequest struct {
+	ReactionType string `json:"reaction_type"`
+}
+
+type Reaction struct {
+	PostId       string    `json:"post_id"`
+	UserId       string    `json:"user_id"`
+	ReactionType string    `json:"reaction_type"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func reactionFromProto(reaction *posts.Reaction) Reaction {
+	return Reaction{
+		PostId:       reaction.PostId,
+		UserId:       reaction.UserId,
+		ReactionType: reaction.ReactionType,
+		CreatedAt:    reaction.CreatedAt.AsTime(),
+	}
+}
+
+func handleSetReaction(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	var reqBody SetReactionRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid input")
+		return
+	}
+	if reqBody.ReactionType == "" {
+		reqBody.ReactionType = "like"
+	}
+
+	req := &posts.SetReactionRequest{
+		PostId:       c.Param("id"),
+		ReactionType: reqBody.ReactionType,
+	}
+	resp, err := client.SetReaction(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to set reaction: %v\n", err)
+		respondGRPCError(c, err, "Failed to set reaction")
+		return
+	}
+	c.JSON(http.StatusOK, reactionFromProto(resp.Reaction))
+}
+
+func handleRemoveReaction(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.RemoveReactionRequest{PostId: c.Param("id")}
+	resp, err := client.RemoveReaction(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to remove reaction: %v\n", err)
+		respondGRPCError(c, err, "Failed to remove reaction")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": resp.Success})
+}
+
+func handleListReactions(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.ListReactionsRequest{
+		PostId:       c.Param("id"),
+		ReactionType: c.Query("reaction_type"),
+		Cursor:       c.Query("cursor"),
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.ListReactions(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch reactions: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch reactions")
+		return
+	}
+
+	reactions := make([]Reaction, 0, len(resp.Reactions))
+	for _, reaction := range resp.Reactions {
+		reactions = append(reactions, reactionFromProto(reaction))
+	}
+	reactionCounts := resp.ReactionCounts
+	if reactionCounts == nil {
+		reactionCounts = map[string]int32{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"reactions":       reactions,
+		"reaction_counts": reactionCounts,
+		"next_cursor":     resp.NextCursor,
+	})
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+type PostRevision struct {
+	PostId      string    `json:"post_id"`
+	Revision    int32     `json:"revision"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	IsPrivate   bool      `json:"is_private"`
+	EditorId    string    `json:"editor_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func revisionFromProto(revision *posts.PostRevision) PostRevision {
+	return PostRevision{
+		PostId:      revision.PostId,
+		Revision:    revision.Revision,
+		Title:       revision.Title,
+		Description: revision.Description,
+		IsPrivate:   revision.IsPrivate,
+		EditorId:    revision.EditorId,
+		CreatedAt:   revision.CreatedAt.AsTime(),
+	}
+}
+
+// revisionParam parses the :revision path parameter, answering 400 if it is
+// not a number.
+func revisionParam(c *gin.Context) (int32, bool) {
+	revision, err := strconv.ParseInt(c.Param("revision"), 10, 32)
+	if err != nil || revision <= 0 {
+		respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid revision format")
+		return 0, false
+	}
+	return int32(revision), true
+}
+
+func handleListPostRevisions(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.ListPostRevisionsRequest{
+		PostId: c.Param("id"),
+		Cursor: c.Query("cursor"),
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.ListPostRevisions(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch revisions: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch revisions")
+		return
+	}
+
+	revisions := make([]PostRevision, 0, len(resp.Revisions))
+	for _, revision := range resp.Revisions {
+		revisions = append(revisions, revisionFromProto(revision))
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions, "next_cursor": resp.NextCursor})
+}
+
+func handleGetPostRevision(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	revision, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+
+	resp, err := client.GetPostRevision(ctx, &posts.GetPostRevisionRequest{PostId: c.Param("id"), Revision: revision})
+	if err != nil {
+		fmt.Printf("Failed to fetch revision: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch revision")
+		return
+	}
+	c.JSON(http.StatusOK, revisionFromProto(resp.Revision))
+}
+
+func handleRestorePostRevision(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	revision, ok := revisionParam(c)
+	if !ok {
+		return
+	}
+	expectedUpdatedAt, ok := ifMatchUpdatedAt(c)
+	if !ok {
+		return
+	}
+
+	resp, err := client.RestorePostRevision(ctx, &posts.RestorePostRevisionRequest{
+		PostId:            c.Param("id"),
+		Revision:          revision,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		fmt.Printf("Failed to restore revision: %v\n", err)
+		respondGRPCError(c, err, "Failed to restore revision")
+		return
+	}
+	post := []Post{postFromProto(resp.Post)}
+	fillPostAuthors(c, post)
+	c.Header("ETag", postETag(post[0].UpdatedAt))
+	c.JSON(http.StatusOK, post[0])
+}
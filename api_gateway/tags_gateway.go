@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+type TagCount struct {
+	Name      string `json:"name"`
+	PostCount int32  `json:"post_count"`
+}
+
+func handleListPopularTags(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.ListPopularTagsRequest{}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.ListPopularTags(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch popular tags: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch popular tags")
+		return
+	}
+
+	tags := make([]TagCount, 0, len(resp.Tags))
+	for _, tag := range resp.Tags {
+		tags = append(tags, TagCount{Name: tag.Name, PostCount: tag.PostCount})
+	}
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+type DeletedPost struct {
+	Post      Post      `json:"post"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+func handleListDeletedPosts(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	req := &posts.ListDeletedPostsRequest{Cursor: c.Query("cursor")}
+	if limit := c.Query("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid limit format")
+			return
+		}
+		req.Limit = int32(parsedLimit)
+	}
+
+	resp, err := client.ListDeletedPosts(ctx, req)
+	if err != nil {
+		fmt.Printf("Failed to fetch deleted posts: %v\n", err)
+		respondGRPCError(c, err, "Failed to fetch deleted posts")
+		return
+	}
+
+	postsList := make([]Post, 0, len(resp.Posts))
+	for _, deleted := range resp.Posts {
+		postsList = append(postsList, postFromProto(deleted.Post))
+	}
+	fillPostAuthors(c, postsList)
+
+	deleted := make([]DeletedPost, 0, len(resp.Posts))
+	for i, d := range resp.Posts {
+		deleted = append(deleted, DeletedPost{
+			Post:      postsList[i],
+			DeletedAt: d.DeletedAt.AsTime(),
+			PurgeAt:   d.PurgeAt.AsTime(),
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"posts": deleted, "next_cursor": resp.NextCursor})
+}
+
+func handleRestorePost(c *gin.Context, postsServiceURL string) {
+	client, ctx, closeConn, err := prepareRequest(c, postsServiceURL)
+	if err != nil {
+		return
+	}
+	defer closeConn()
+
+	resp, err := client.RestorePost(ctx, &posts.RestorePostRequest{PostId: c.Param("id")})
+	if err != nil {
+		fmt.Printf("Failed to restore post: %v\n", err)
+		respondGRPCError(c, err, "Failed to restore post")
+		return
+	}
+	post := []Post{postFromProto(resp.Post)}
+	fillPostAuthors(c, post)
+	c.Header("ETag", postETag(post[0].UpdatedAt))
+	c.JSON(http.StatusOK, post[0])
+}
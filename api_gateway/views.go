@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/events"
+	"msg.i3cheese.ru/proto/statistics"
+)
+
+const (
+	// viewDedupWindow is how long repeated views of a post by one user are
+	// counted as one.
+	viewDedupWindow = 30 * time.Minute
+	// viewQueueSize is how many views may wait to be published. Views beyond
+	// it are dropped rather than delaying responses.
+	viewQueueSize = 1024
+	// viewCountTimeout bounds how long a response waits for view counts.
+	viewCountTimeout = 300 * time.Millisecond
+)
+
+// viewRecorder is nil when neither KAFKA_BROKERS nor STATISTICS_URL is set;
+// views are then not recorded.
+var viewRecorder *ViewRecorder
+
+// statisticsClient is nil when STATISTICS_URL is not set; posts then have no
+// view_count.
+var statisticsClient statistics.StatisticsServiceClient
+
+// setupStatistics connects to the statistics pipeline if it is configured.
+// Views go to Kafka when KAFKA_BROKERS is set and straight to the statistics
+// service otherwise.
+func setupStatistics() {
+	if statisticsURL := os.Getenv("STATISTICS_URL"); statisticsURL != "" {
+		conn, err := grpc.NewClient(statisticsURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			fmt.Printf("Failed to create statistics client: %v\n", err)
+			os.Exit(1)
+		}
+		statisticsClient = statistics.NewStatisticsServiceClient(conn)
+	}
+
+	var publisher ViewPublisher
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+		topic := os.Getenv("POST_VIEWS_TOPIC")
+		if topic == "" {
+			topic = "post-views"
+		}
+		publisher = NewKafkaViewPublisher(strings.Split(brokers, ","), topic)
+	} else if statisticsClient != nil {
+		publisher = &StatisticsViewPublisher{Client: statisticsClient}
+	}
+	if publisher != nil {
+		viewRecorder = NewViewRecorder(publisher, viewDedupWindow)
+		go viewRecorder.Run()
+	}
+}
+
+// ViewPublisher sends view events to the statistics pipeline.
+type ViewPublisher interface {
+	PublishView(ctx context.Context, view *events.PostViewed) error
+}
+
+// KafkaViewPublisher publishes views to a Kafka topic keyed by post id.
+type KafkaViewPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaViewPublisher(brokers []string, topic string) *KafkaViewPublisher {
+	return &KafkaViewPublisher{writer: &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		BatchTimeout:           50 * time.Millisecond,
+		AllowAutoTopicCreation: true,
+	}}
+}
+
+func (p *KafkaViewPublisher) PublishView(ctx context.Context, view *events.PostViewed) error {
+	value, err := proto.Marshal(view)
+	if err != nil {
+		return fmt.Errorf("failed to encode view: %w", err)
+	}
+	return p.writer.WriteMessages(ctx, kafka.Message{Key: []byte(view.PostId), Value: value})
+}
+
+// StatisticsViewPublisher sends views straight to the statistics service, for
+// deployments without Kafka.
+type StatisticsViewPublisher struct {
+	Client statistics.StatisticsServiceClient
+}
+
+func (p *StatisticsViewPublisher) PublishView(ctx context.Context, view *events.PostViewed) error {
+	value, err := proto.Marshal(view)
+	if err != nil {
+		return fmt.Errorf("failed to encode view: %w", err)
+	}
+	_, err = p.Client.PublishEvents(ctx, &statistics.PublishEventsRequest{PostViews: [][]byte{value}})
+	return err
+}
+
+type viewKey struct {
+	userId string
+	postId string
+}
+
+// ViewRecorder publishes views in the background so responses never wait for
+// the broker. Repeated views are deduplicated per gateway instance, so with
+// several instances a view may be counted once per instance.
+type ViewRecorder struct {
+	Publisher ViewPublisher
+	Window    time.Duration
+
+	mu       sync.Mutex
+	lastSeen map[viewKey]time.Time
+	queue    chan *events.PostViewed
+}
+
+func NewViewRecorder(publisher ViewPublisher, window time.Duration) *ViewRecorder {
+	return &ViewRecorder{
+		Publisher: publisher,
+		Window:    window,
+		lastSeen:  make(map[viewKey]time.Time),
+		queue:     make(chan *events.PostViewed, viewQueueSize),
+	}
+}
+
+// Record queues a view unless the user viewed the post within the window.
+func (r *ViewRecorder) Record(userId, postId, userAgent, ip string) {
+	now := time.Now()
+	key := viewKey{userId: userId, postId: postId}
+
+	r.mu.Lock()
+	if seen, ok := r.lastSeen[key]; ok && now.Sub(seen) < r.Window {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSeen[key] = now
+	r.mu.Unlock()
+
+	view := &events.PostViewed{
+		EventId:    newEventId(),
+		OccurredAt: timestamppb.New(now),
+		PostId:     postId,
+		UserId:     userId,
+		UserAgent:  userAgent,
+		Ip:         ip,
+	}
+	select {
+	case r.queue <- view:
+	default:
+		fmt.Printf("Dropping view of post %s: queue is full\n", postId)
+	}
+}
+
+// Run publishes queued views and forgets views older than the window.
+func (r *ViewRecorder) Run() {
+	sweep := time.NewTicker(r.Window)
+	defer sweep.Stop()
+	for {
+		select {
+		case view := <-r.queue:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := r.Publisher.PublishView(ctx, view); err != nil {
+				fmt.Printf("Failed to publish view of post %s: %v\n", view.PostId, err)
+			}
+			cancel()
+		case now := <-sweep.C:
+			r.mu.Lock()
+			for key, seen := range r.lastSeen {
+				if now.Sub(seen) >= r.Window {
+					delete(r.lastSeen, key)
+				}
+			}
+			r.mu.Unlock()
+		}
+	}
+}
+
+// newEventId returns a random UUID.
+func newEventId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// fillViewCounts sets ViewCount of the posts from the statistics service. If
+// it does not answer in time the posts are left without a view count, so
+// statistics being down never fails a request.
+func fillViewCounts(c *gin.Context, postsList []Post) {
+	if statisticsClient == nil || len(postsList) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), viewCountTimeout)
+	defer cancel()
+
+	postIds := make([]string, 0, len(postsList))
+	for _, post := range postsList {
+		postIds = append(postIds, post.PostId)
+	}
+	resp, err := statisticsClient.GetPostStatsBatch(ctx, &statistics.GetPostStatsBatchRequest{PostIds: postIds})
+	if err != nil {
+		fmt.Printf("Failed to fetch view counts: %v\n", err)
+		return
+	}
+	for i, stats := range resp.Stats {
+		if i < len(postsList) && stats.PostId == postsList[i].PostId {
+			views := stats.Views
+			postsList[i].ViewCount = &views
+		}
+	}
+}
@@ -1,4 +1,6 @@
-DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS refresh_tokens, sessions, subscriptions, users;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 CREATE TABLE users (
     user_id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
     login VARCHAR(255) NOT NULL UNIQUE,
@@ -8,6 +10,49 @@ CREATE TABLE users (
     surname VARCHAR(255) NOT NULL,
     date_of_birth DATE NOT NULL,
     phone_number VARCHAR(20) NOT NULL,
+    display_name VARCHAR(64) NOT NULL DEFAULT '',
+    avatar_url VARCHAR(2048) NOT NULL DEFAULT '',
+    bio VARCHAR(500) NOT NULL DEFAULT '',
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Everything users are searched by, lowercased.
+    search_text TEXT GENERATED ALWAYS AS (
+        lower(login || ' ' || name || ' ' || surname || ' ' || display_name)
+    ) STORED
 );
+
+-- Trigram index for substring and fuzzy user search.
+CREATE INDEX users_search_text_idx ON users USING GIN (search_text gin_trgm_ops);
+
+CREATE TABLE subscriptions (
+    subscriber_id VARCHAR(36) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    subscribed_to_id VARCHAR(36) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (subscriber_id, subscribed_to_id),
+    CHECK (subscriber_id <> subscribed_to_id)
+);
+
+CREATE INDEX subscriptions_subscriber_idx ON subscriptions (subscriber_id, created_at, subscribed_to_id);
+CREATE INDEX subscriptions_subscribed_to_idx ON subscriptions (subscribed_to_id, created_at, subscriber_id);
+
+CREATE TABLE sessions (
+    session_id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(36) NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    terminated BOOLEAN NOT NULL DEFAULT FALSE,
+    terminated_at TIMESTAMP
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id, created_at);
+
+CREATE TABLE refresh_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    session_id VARCHAR(36) NOT NULL REFERENCES sessions(session_id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMP
+);
+
+CREATE INDEX refresh_tokens_session_id_idx ON refresh_tokens (session_id);
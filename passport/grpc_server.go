@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"msg.i3cheese.ru/proto/passport"
+)
+
+// PassportServiceServer exposes the same operations as the HTTP routes to
+// other services over gRPC.
+type PassportServiceServer struct {
+	passport.UnimplementedPassportServiceServer
+	App *App
+}
+
+func (s *PassportServiceServer) Register(ctx context.Context, req *passport.RegisterRequest) (*passport.RegisterResponse, error) {
+	userID, err := s.App.registerUser(RegisterRequest{
+		Login:       req.Login,
+		Email:       req.Email,
+		Password:    req.Password,
+		Name:        req.Name,
+		Surname:     req.Surname,
+		DateOfBirth: req.DateOfBirth,
+		PhoneNumber: req.PhoneNumber,
+	})
+	if errors.Is(err, errUserExists) {
+		return nil, status.Error(codes.AlreadyExists, "user with this login or email already exists")
+	}
+	if err != nil {
+		fmt.Printf("Failed to register user: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to register user: %v", err)
+	}
+
+	return &passport.RegisterResponse{UserId: userID}, nil
+}
+
+func (s *PassportServiceServer) Login(ctx context.Context, req *passport.LoginRequest) (*passport.LoginResponse, error) {
+	tokens, err := s.App.login(req.Login, req.Password, req.UserAgent, req.Ip)
+	if errors.Is(err, errInvalidCredentials) {
+		return nil, status.Error(codes.Unauthenticated, "invalid login or password")
+	}
+	if err != nil {
+		fmt.Printf("Failed to log in: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to log in: %v", err)
+	}
+
+	return &passport.LoginResponse{
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    int32(tokens.ExpiresIn),
+		UserId:       tokens.UserId,
+		SessionId:    tokens.SessionId,
+	}, nil
+}
+
+func (s *PassportServiceServer) ValidateToken(ctx context.Context, req *passport.ValidateTokenRequest) (*passport.ValidateTokenResponse, error) {
+	userID, sessionID, err := s.App.validateToken(req.Token)
+	if errors.Is(err, errInvalidToken) || errors.Is(err, errSessionNotActive) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		fmt.Printf("Failed to check token: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to check token: %v", err)
+	}
+
+	return &passport.ValidateTokenResponse{UserId: userID, SessionId: sessionID}, nil
+}
+
+func (s *PassportServiceServer) GetUser(ctx context.Context, req *passport.GetUserRequest) (*passport.GetUserResponse, error) {
+	user, err := s.App.getUser(req.UserId)
+	if errors.Is(err, errUserNotFound) {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", req.UserId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to find user with id %s: %v\n", req.UserId, err)
+		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
+	}
+
+	return &passport.GetUserResponse{User: user}, nil
+}
+
+func (s *PassportServiceServer) BatchGetUsers(ctx context.Context, req *passport.BatchGetUsersRequest) (*passport.BatchGetUsersResponse, error) {
+	users, err := s.App.getUsers(req.UserIds)
+	if err != nil {
+		fmt.Printf("Failed to fetch users: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch users: %v", err)
+	}
+
+	return &passport.BatchGetUsersResponse{Users: users}, nil
+}
+
+func (s *PassportServiceServer) UpdateUser(ctx context.Context, req *passport.UpdateUserRequest) (*passport.UpdateUserResponse, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("actor_user_id")) == 0 {
+		fmt.Printf("Failed to get actor from metadata\n")
+		return nil, status.Error(codes.Unauthenticated, "actor_user_id metadata is required")
+	}
+	actorUserId := md.Get("actor_user_id")[0]
+
+	err := s.App.updateUser(actorUserId, UpdateUserRequest{
+		Email:       req.Email,
+		Name:        req.Name,
+		Surname:     req.Surname,
+		DateOfBirth: req.DateOfBirth,
+		PhoneNumber: req.PhoneNumber,
+		DisplayName: req.DisplayName,
+		AvatarUrl:   req.AvatarUrl,
+		Bio:         req.Bio,
+	})
+	if errors.Is(err, errInvalidProfile) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, errUserNotFound) {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", actorUserId)
+	}
+	if errors.Is(err, errUserExists) {
+		return nil, status.Error(codes.AlreadyExists, "email is already taken")
+	}
+	if err != nil {
+		fmt.Printf("Failed to update user: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+	}
+
+	user, err := s.App.getUser(actorUserId)
+	if err != nil {
+		fmt.Printf("Failed to find user with id %s: %v\n", actorUserId, err)
+		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
+	}
+	return &passport.UpdateUserResponse{User: user}, nil
+}
+
+func (s *PassportServiceServer) GetProfile(ctx context.Context, req *passport.GetProfileRequest) (*passport.GetProfileResponse, error) {
+	var profile *passport.Profile
+	var err error
+	switch user := req.User.(type) {
+	case *passport.GetProfileRequest_UserId:
+		profile, err = s.App.getProfile("user_id", user.UserId)
+	case *passport.GetProfileRequest_Login:
+		profile, err = s.App.getProfile("login", user.Login)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "user_id or login is required")
+	}
+	if errors.Is(err, errUserNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch profile: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch profile: %v", err)
+	}
+
+	return &passport.GetProfileResponse{Profile: profile}, nil
+}
+
+func (s *PassportServiceServer) BatchGetProfiles(ctx context.Context, req *passport.BatchGetProfilesRequest) (*passport.BatchGetProfilesResponse, error) {
+	if len(req.UserIds) > maxProfilesBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d user_ids are allowed", maxProfilesBatchSize)
+	}
+	profiles, err := s.App.getProfiles(req.UserIds)
+	if err != nil {
+		fmt.Printf("Failed to fetch profiles: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch profiles: %v", err)
+	}
+
+	return &passport.BatchGetProfilesResponse{Profiles: profiles}, nil
+}
+
+func (s *PassportServiceServer) SearchUsers(ctx context.Context, req *passport.SearchUsersRequest) (*passport.SearchUsersResponse, error) {
+	profiles, err := s.App.searchProfiles(req.Query, int(req.Limit))
+	if errors.Is(err, errInvalidSearch) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		fmt.Printf("Failed to search users: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to search users: %v", err)
+	}
+
+	return &passport.SearchUsersResponse{Profiles: profiles}, nil
+}
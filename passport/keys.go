@@ -0,0 +1,184 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// SigningKey is a private key tokens can be signed with. Its kid is the name
+// of the file it was loaded from.
+type SigningKey struct {
+	Kid    string
+	Method jwt.SigningMethod
+	Key    crypto.Signer
+}
+
+// KeyRing holds every key found in the keys directory. All of them are
+// published in the JWKS and accepted when verifying, but only Current signs
+// new tokens, so a key can be rotated in ahead of use and kept around until
+// the last token signed with it has expired.
+type KeyRing struct {
+	Keys    map[string]*SigningKey
+	Current *SigningKey
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// LoadKeyRing reads every <kid>.pem file from dir. The signing key is
+// currentKid or, if it is empty, the key whose kid sorts last. An empty
+// directory gets a freshly generated Ed25519 key so a development setup works
+// out of the box.
+func LoadKeyRing(dir string, currentKid string) (*KeyRing, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		path, err := generateSigningKeyFile(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %v", err)
+		}
+		fmt.Printf("Generated signing key %s\n", path)
+		paths = []string{path}
+	}
+
+	ring := &KeyRing{Keys: make(map[string]*SigningKey, len(paths))}
+	kids := make([]string, 0, len(paths))
+	for _, path := range paths {
+		key, err := loadSigningKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %v", path, err)
+		}
+		ring.Keys[key.Kid] = key
+		kids = append(kids, key.Kid)
+	}
+
+	if currentKid == "" {
+		slices.Sort(kids)
+		currentKid = kids[len(kids)-1]
+	}
+	ring.Current = ring.Keys[currentKid]
+	if ring.Current == nil {
+		return nil, fmt.Errorf("signing key %q not found in %s", currentKid, dir)
+	}
+	return ring, nil
+}
+
+func loadSigningKey(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	var parsed any
+	if block.Type == "RSA PRIVATE KEY" {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &SigningKey{Kid: kid, Method: jwt.SigningMethodRS256, Key: key}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{Kid: kid, Method: jwt.SigningMethodEdDSA, Key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+}
+
+func generateSigningKeyFile(dir string) (string, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, time.Now().UTC().Format("20060102T150405Z")+".pem")
+	return path, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+}
+
+// Sign signs claims with the current key and names it in the kid header.
+func (ring *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ring.Current.Method, claims)
+	token.Header["kid"] = ring.Current.Kid
+	return token.SignedString(ring.Current.Key)
+}
+
+// Keyfunc picks the verification key named by the token's kid header and
+// rejects tokens whose algorithm does not match that key.
+func (ring *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := ring.Keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+	return key.Key.Public(), nil
+}
+
+// JWKS returns the public halves of every key in the ring.
+func (ring *KeyRing) JWKS() []JWK {
+	keys := make([]JWK, 0, len(ring.Keys))
+	for _, key := range ring.Keys {
+		jwk := JWK{Kid: key.Kid, Use: "sig", Alg: key.Method.Alg()}
+		switch public := key.Key.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		keys = append(keys, jwk)
+	}
+	slices.SortFunc(keys, func(a, b JWK) int { return strings.Compare(a.Kid, b.Kid) })
+	return keys
+}
+
+func (app *App) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": app.Keys.JWKS()})
+}
@@ -2,20 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"msg.i3cheese.ru/proto/passport"
 )
 
 type App struct {
-	DB *pgx.Conn
+	DB   *pgxpool.Pool
+	Keys *KeyRing
 }
 
 type RegisterRequest struct {
@@ -39,6 +42,9 @@ type UpdateUserRequest struct {
 	Surname     string `json:"surname"`
 	DateOfBirth string `json:"date_of_birth"`
 	PhoneNumber string `json:"phone_number"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url"`
+	Bio         string `json:"bio"`
 }
 
 func (app *App) Register(c *gin.Context) {
@@ -49,18 +55,11 @@ func (app *App) Register(c *gin.Context) {
 		return
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
-		fmt.Printf("Failed to hash password: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+	_, err := app.registerUser(user)
+	if errors.Is(err, errUserExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": "User with this login or email already exists"})
 		return
 	}
-	now := time.Now()
-	_, err = app.DB.Exec(
-		context.Background(),
-		"INSERT INTO users (login, email, hashed_password, name, surname, date_of_birth, phone_number, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
-		user.Login, user.Email, string(hashedPassword), user.Name, user.Surname, user.DateOfBirth, user.PhoneNumber, now, now,
-	)
 	if err != nil {
 		fmt.Printf("Failed to register user: %v\n", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register user"})
@@ -78,35 +77,18 @@ func (app *App) Login(c *gin.Context) {
 		return
 	}
 
-	var hashedPassword string
-	var user_id string
-	err := app.DB.QueryRow(context.Background(), "SELECT hashed_password, user_id FROM users WHERE login=$1", loginReq.Login).Scan(&hashedPassword, &user_id)
-	if err != nil {
-		fmt.Printf("Failed to find user: %v\n", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid login"})
+	tokens, err := app.login(loginReq.Login, loginReq.Password, c.Request.UserAgent(), c.ClientIP())
+	if errors.Is(err, errInvalidCredentials) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid login or password"})
 		return
 	}
-
-	err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(loginReq.Password))
-	if err != nil {
-		fmt.Printf("Failed to compare passwords: %v\n", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid password"})
-		return
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user_id,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(),
-	})
-
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
 	if err != nil {
-		fmt.Printf("Failed to generate token: %v\n", err)
+		fmt.Printf("Failed to log in: %v\n", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": tokenString, "user_id": user_id})
+	c.JSON(http.StatusOK, tokens)
 }
 
 type CheckTokenRequest struct {
@@ -121,24 +103,22 @@ func (app *App) CheckToken(c *gin.Context) {
 		return
 	}
 
-	token, err := jwt.Parse(tokenReq.Token, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("JWT_SECRET")), nil
-	})
-	if err != nil {
-		fmt.Printf("Failed to parse token: %v\n", err)
+	userID, sessionID, err := app.validateToken(tokenReq.Token)
+	if errors.Is(err, errInvalidToken) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 		return
 	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		fmt.Printf("Failed to validate token: %v\n", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+	if errors.Is(err, errSessionNotActive) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session is not active"})
+		return
+	}
+	if err != nil {
+		fmt.Printf("Failed to check token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check session"})
 		return
 	}
-	fmt.Printf("claims: %v\n", claims)
 
-	c.JSON(http.StatusOK, gin.H{"user_id": claims["user_id"]})
+	c.JSON(http.StatusOK, gin.H{"user_id": userID, "session_id": sessionID})
 }
 
 type UserInfo struct {
@@ -148,6 +128,9 @@ type UserInfo struct {
 	Surname     string `json:"surname"`
 	DateOfBirth string `json:"date_of_birth"`
 	PhoneNumber string `json:"phone_number"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url"`
+	Bio         string `json:"bio"`
 }
 
 func (app *App) GetMyInfo(c *gin.Context) {
@@ -159,17 +142,24 @@ func (app *App) GetMyInfo(c *gin.Context) {
 		return
 	}
 
-	var user UserInfo
-	var date_of_birth pgtype.Date
-	err := app.DB.QueryRow(context.Background(), "SELECT login, email, name, surname, date_of_birth, phone_number FROM users WHERE user_id=$1", userID).Scan(&user.Login, &user.Email, &user.Name, &user.Surname, &date_of_birth, &user.PhoneNumber)
-	user.DateOfBirth = date_of_birth.Time.Format("2006-01-02")
+	user, err := app.getUser(userID)
 	if err != nil {
 		fmt.Printf("Failed to find user with id %s: %v\n", userID, err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, UserInfo{
+		Login:       user.Login,
+		Email:       user.Email,
+		Name:        user.Name,
+		Surname:     user.Surname,
+		DateOfBirth: user.DateOfBirth,
+		PhoneNumber: user.PhoneNumber,
+		DisplayName: user.DisplayName,
+		AvatarUrl:   user.AvatarUrl,
+		Bio:         user.Bio,
+	})
 }
 
 func (app *App) UpdateMyInfo(c *gin.Context) {
@@ -187,11 +177,15 @@ func (app *App) UpdateMyInfo(c *gin.Context) {
 		return
 	}
 
-	_, err := app.DB.Exec(
-		context.Background(),
-		"UPDATE users SET email=$1, name=$2, surname=$3, date_of_birth=$4, phone_number=$5, updated_at=$6 WHERE user_id=$7",
-		user.Email, user.Name, user.Surname, user.DateOfBirth, user.PhoneNumber, time.Now(), userID,
-	)
+	err := app.updateUser(userID, user)
+	if errors.Is(err, errInvalidProfile) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, errUserExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Email is already taken"})
+		return
+	}
 	if err != nil {
 		fmt.Printf("Failed to update user: %v\n", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user"})
@@ -201,14 +195,20 @@ func (app *App) UpdateMyInfo(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "User updated successfully"})
 }
 
-func connectWithRetries(ctx context.Context, dsn string, maxRetries int) (*pgx.Conn, error) {
-	var conn *pgx.Conn
+func connectWithRetries(ctx context.Context, dsn string, maxRetries int) (*pgxpool.Pool, error) {
+	var conn *pgxpool.Pool
 	var err error
 	for i := 0; i < maxRetries; i++ {
-		conn, err = pgx.Connect(ctx, dsn)
+		conn, err = pgxpool.New(ctx, dsn)
+		if err == nil {
+			err = conn.Ping(ctx)
+		}
 		if err == nil {
 			return conn, nil
 		}
+		if conn != nil {
+			conn.Close()
+		}
 		fmt.Fprintf(os.Stderr, "Attempt %d: Unable to connect to database: %v\n", i+1, err)
 		time.Sleep(2 * time.Second) // Add a delay between retries
 	}
@@ -221,16 +221,66 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Unable to connect to database after retries: %v\n", err)
 		os.Exit(1)
 	}
-	defer conn.Close(context.Background())
+	defer conn.Close()
 
-	app := &App{DB: conn}
+	keysDir := os.Getenv("JWT_KEYS_DIR")
+	if keysDir == "" {
+		keysDir = "keys"
+	}
+	keys, err := LoadKeyRing(keysDir, os.Getenv("JWT_SIGNING_KEY_ID"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to load signing keys: %v\n", err)
+		os.Exit(1)
+	}
+
+	app := &App{DB: conn, Keys: keys}
 
 	router := gin.Default()
+	router.GET("/.well-known/jwks.json", app.GetJWKS)
 	router.POST("/register", app.Register)
 	router.POST("/login", app.Login)
 	router.GET("/check_token", app.CheckToken)
+	router.POST("/refresh", app.Refresh)
+	router.POST("/logout", app.Logout)
+	router.GET("/sessions", app.ListSessions)
+	router.DELETE("/sessions/:id", app.TerminateSession)
+	router.GET("/revoked_sessions", app.ListRevokedSessions)
 	router.GET("/me", app.GetMyInfo)
 	router.PUT("/me", app.UpdateMyInfo)
+	router.POST("/users/:id/follow", app.Follow)
+	router.DELETE("/users/:id/follow", app.Unfollow)
+	router.GET("/users/:id/followers", app.ListFollowers)
+	router.GET("/users/:id/following", app.ListFollowing)
+	router.GET("/users/:id/following/:target_id", app.IsFollowing)
+	router.GET("/users/:id/follow_counts", app.GetFollowCounts)
+	router.GET("/users/:id/following_ids", app.ListFollowingIds)
+	router.GET("/users/:id", app.GetProfile)
+	router.GET("/users/by-login/:login", app.GetProfileByLogin)
+	router.GET("/users/search", app.SearchUsers)
+
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		fmt.Println("GRPC_PORT is required")
+		os.Exit(1)
+	}
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to listen on port %s: %v\n", grpcPort, err)
+		os.Exit(1)
+	}
+	// The gRPC server runs alongside the router and shares app, so both go
+	// through the same connection pool.
+	grpcServer := grpc.NewServer()
+	passport.RegisterPassportServiceServer(grpcServer, &PassportServiceServer{App: app})
+	// Enable reflection for debugging
+	reflection.Register(grpcServer)
+	go func() {
+		fmt.Printf("gRPC server is running on port %s\n", grpcPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to serve gRPC server: %v\n", err)
+			os.Exit(1)
+		}
+	}()
 
 	router.Run(fmt.Sprintf("0.0.0.0:%s", os.Getenv("PORT")))
 }
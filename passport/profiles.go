@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/passport"
+)
+
+const (
+	maxDisplayNameLength = 64
+	maxAvatarURLLength   = 2048
+	maxBioLength         = 500
+
+	defaultUserSearchLimit = 20
+	maxUserSearchLimit     = 50
+	maxUserSearchQueryLen  = 64
+	maxProfilesBatchSize   = 1000
+)
+
+var errInvalidSearch = errors.New("invalid search")
+
+// Profile is the public part of a user, safe to show to anyone.
+type Profile struct {
+	UserId         string    `json:"user_id"`
+	Login          string    `json:"login"`
+	DisplayName    string    `json:"display_name"`
+	AvatarUrl      string    `json:"avatar_url"`
+	Bio            string    `json:"bio"`
+	FollowersCount int32     `json:"followers_count"`
+	FollowingCount int32     `json:"following_count"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func profileFromProto(profile *passport.Profile) Profile {
+	return Profile{
+		UserId:         profile.UserId,
+		Login:          profile.Login,
+		DisplayName:    profile.DisplayName,
+		AvatarUrl:      profile.AvatarUrl,
+		Bio:            profile.Bio,
+		FollowersCount: profile.FollowersCount,
+		FollowingCount: profile.FollowingCount,
+		CreatedAt:      profile.CreatedAt.AsTime(),
+	}
+}
+
+// validateProfile checks the public fields a user may edit.
+func validateProfile(user UpdateUserRequest) error {
+	if utf8.RuneCountInString(user.DisplayName) > maxDisplayNameLength {
+		return fmt.Errorf("%w: display_name is longer than %d characters", errInvalidProfile, maxDisplayNameLength)
+	}
+	if utf8.RuneCountInString(user.Bio) > maxBioLength {
+		return fmt.Errorf("%w: bio is longer than %d characters", errInvalidProfile, maxBioLength)
+	}
+	if user.AvatarUrl != "" {
+		if len(user.AvatarUrl) > maxAvatarURLLength {
+			return fmt.Errorf("%w: avatar_url is longer than %d characters", errInvalidProfile, maxAvatarURLLength)
+		}
+		parsed, err := url.Parse(user.AvatarUrl)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%w: avatar_url must be an http or https URL", errInvalidProfile)
+		}
+	}
+	return nil
+}
+
+// profileColumns selects a Profile from users aliased as u. The display name
+// falls back to the name and surname.
+const profileColumns = `u.user_id, u.login,
+	COALESCE(NULLIF(u.display_name, ''), u.name || ' ' || u.surname),
+	u.avatar_url, u.bio,
+	(SELECT COUNT(*) FROM subscriptions WHERE subscribed_to_id = u.user_id),
+	(SELECT COUNT(*) FROM subscriptions WHERE subscriber_id = u.user_id),
+	u.created_at`
+
+func scanProfile(row pgx.Row) (*passport.Profile, error) {
+	var profile passport.Profile
+	var createdAt time.Time
+	err := row.Scan(&profile.UserId, &profile.Login, &profile.DisplayName, &profile.AvatarUrl, &profile.Bio,
+		&profile.FollowersCount, &profile.FollowingCount, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	profile.CreatedAt = timestamppb.New(createdAt)
+	return &profile, nil
+}
+
+// getProfile finds the profile of the user whose column (user_id or login)
+// equals value.
+func (app *App) getProfile(column string, value string) (*passport.Profile, error) {
+	query := fmt.Sprintf("SELECT %s FROM users u WHERE u.%s = $1", profileColumns, column)
+	profile, err := scanProfile(app.DB.QueryRow(context.Background(), query, value))
+	if err == pgx.ErrNoRows {
+		return nil, errUserNotFound
+	}
+	return profile, err
+}
+
+// getProfiles returns the profiles of the users among userIDs that exist,
+// in no particular order.
+func (app *App) getProfiles(userIDs []string) ([]*passport.Profile, error) {
+	rows, err := app.DB.Query(context.Background(), "SELECT "+profileColumns+" FROM users u WHERE u.user_id = ANY($1)", userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []*passport.Profile{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// searchProfiles finds users whose login, name, surname or display name
+// contains query, or has a word similar to it.
+func (app *App) searchProfiles(query string, limit int) ([]*passport.Profile, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, fmt.Errorf("%w: query is required", errInvalidSearch)
+	}
+	if utf8.RuneCountInString(query) > maxUserSearchQueryLen {
+		return nil, fmt.Errorf("%w: query is longer than %d characters", errInvalidSearch, maxUserSearchQueryLen)
+	}
+	if limit <= 0 {
+		limit = defaultUserSearchLimit
+	}
+	limit = min(limit, maxUserSearchLimit)
+
+	// Both conditions can use the trigram index on search_text.
+	rows, err := app.DB.Query(
+		context.Background(),
+		`SELECT `+profileColumns+`
+		FROM users u
+		WHERE u.search_text LIKE '%' || $2 || '%' OR $1 <% u.search_text
+		ORDER BY lower(u.login) = $1 DESC,
+		         lower(u.login) LIKE $2 || '%' DESC,
+		         word_similarity($1, u.search_text) DESC,
+		         u.login
+		LIMIT $3`,
+		query, escapeLike(query), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []*passport.Profile{}
+	for rows.Next() {
+		profile, err := scanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+func (app *App) GetProfile(c *gin.Context) {
+	app.respondProfile(c, "user_id", c.Param("id"))
+}
+
+func (app *App) GetProfileByLogin(c *gin.Context) {
+	app.respondProfile(c, "login", c.Param("login"))
+}
+
+func (app *App) respondProfile(c *gin.Context, column string, value string) {
+	profile, err := app.getProfile(column, value)
+	if errors.Is(err, errUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch profile: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profileFromProto(profile))
+}
+
+func (app *App) SearchUsers(c *gin.Context) {
+	limit := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit format"})
+			return
+		}
+	}
+
+	profiles, err := app.searchProfiles(c.Query("q"), limit)
+	if errors.Is(err, errInvalidSearch) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		fmt.Printf("Failed to search users: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search users"})
+		return
+	}
+
+	users := make([]Profile, 0, len(profiles))
+	for _, profile := range profiles {
+		users = append(users, profileFromProto(profile))
+	}
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
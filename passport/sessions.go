@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sessionTTL bounds the session and so the refresh token family issued for
+// it; access tokens live for accessTokenTTL and are renewed within it.
+const sessionTTL = 30 * 24 * time.Hour
+
+// revokedSessionsOverlap is subtracted from the since returned by
+// ListRevokedSessions. terminated_at is the start of the terminating
+// transaction, which may commit after a poll that already moved past it, so
+// consecutive polls overlap by more than any such transaction takes. All
+// times come from the database clock, so there is no skew to allow for.
+const revokedSessionsOverlap = time.Minute
+
+type Session struct {
+	SessionId string    `json:"session_id"`
+	UserAgent string    `json:"user_agent"`
+	Ip        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Current   bool      `json:"current"`
+}
+
+func (app *App) createSession(userID string, userAgent string, ip string) (string, error) {
+	var sessionID string
+	err := app.DB.QueryRow(
+		context.Background(),
+		"INSERT INTO sessions (user_id, user_agent, ip, expires_at) VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4)) RETURNING session_id",
+		userID, userAgent, ip, sessionTTL.Seconds(),
+	).Scan(&sessionID)
+	return sessionID, err
+}
+
+// isSessionActive reports whether the session belongs to the user and has
+// neither expired nor been terminated.
+func (app *App) isSessionActive(sessionID string, userID string) (bool, error) {
+	var active bool
+	err := app.DB.QueryRow(
+		context.Background(),
+		"SELECT EXISTS (SELECT 1 FROM sessions WHERE session_id=$1 AND user_id=$2 AND NOT terminated AND expires_at > CURRENT_TIMESTAMP)",
+		sessionID, userID,
+	).Scan(&active)
+	return active, err
+}
+
+// terminateSession ends a session of the user, reporting false if there was
+// no such active session.
+func (app *App) terminateSession(sessionID string, userID string) (bool, error) {
+	tag, err := app.DB.Exec(
+		context.Background(),
+		"UPDATE sessions SET terminated=TRUE, terminated_at=CURRENT_TIMESTAMP WHERE session_id=$1 AND user_id=$2 AND NOT terminated",
+		sessionID, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (app *App) Logout(c *gin.Context) {
+	// Get user and session IDs from headers
+	userID := c.GetHeader("X-User-Id")
+	sessionID := c.GetHeader("X-Session-Id")
+	if userID == "" || sessionID == "" {
+		fmt.Println("Failed to get user or session ID from header")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	_, err := app.terminateSession(sessionID, userID)
+	if err != nil {
+		fmt.Printf("Failed to terminate session: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Logged out successfully"})
+}
+
+func (app *App) ListSessions(c *gin.Context) {
+	// Get user ID from header
+	userID := c.GetHeader("X-User-Id")
+	if userID == "" {
+		fmt.Println("Failed to get user ID from header")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	currentSessionID := c.GetHeader("X-Session-Id")
+
+	rows, err := app.DB.Query(
+		context.Background(),
+		`SELECT session_id, user_agent, ip, created_at, expires_at FROM sessions
+		WHERE user_id=$1 AND NOT terminated AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		fmt.Printf("Failed to list sessions: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions"})
+		return
+	}
+	defer rows.Close()
+
+	sessions := []Session{}
+	for rows.Next() {
+		var session Session
+		if err := rows.Scan(&session.SessionId, &session.UserAgent, &session.Ip, &session.CreatedAt, &session.ExpiresAt); err != nil {
+			fmt.Printf("Failed to scan session: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions"})
+			return
+		}
+		session.Current = session.SessionId == currentSessionID
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+func (app *App) TerminateSession(c *gin.Context) {
+	// Get user ID from header
+	userID := c.GetHeader("X-User-Id")
+	if userID == "" {
+		fmt.Println("Failed to get user ID from header")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	terminated, err := app.terminateSession(c.Param("id"), userID)
+	if err != nil {
+		fmt.Printf("Failed to terminate session: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to terminate session"})
+		return
+	}
+	if !terminated {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "Session terminated"})
+}
+
+type RevokedSession struct {
+	SessionId string    `json:"session_id"`
+	Until     time.Time `json:"until"`
+}
+
+// ListRevokedSessions returns sessions terminated at or after ?since whose
+// access tokens may still be unexpired, together with the time to pass as
+// since on the next call (as_of). Consecutive polls overlap, so sessions may
+// be reported more than once. Services that verify access tokens themselves poll
+// it to keep a denylist; it is not exposed through the gateway.
+func (app *App) ListRevokedSessions(c *gin.Context) {
+	since := time.Time{}
+	if sinceParam := c.Query("since"); sinceParam != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339Nano, sinceParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since format"})
+			return
+		}
+	}
+
+	ctx := context.Background()
+	var asOf time.Time
+	if err := app.DB.QueryRow(ctx, "SELECT CURRENT_TIMESTAMP::timestamp").Scan(&asOf); err != nil {
+		fmt.Printf("Failed to get current time: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list revoked sessions"})
+		return
+	}
+
+	// No access token outlives its session or accessTokenTTL past the
+	// termination, so older revocations need not be reported.
+	rows, err := app.DB.Query(
+		ctx,
+		`SELECT session_id, LEAST(expires_at, terminated_at + make_interval(secs => $2)) AS until
+		FROM sessions
+		WHERE terminated AND terminated_at >= $1
+		  AND LEAST(expires_at, terminated_at + make_interval(secs => $2)) > $3`,
+		since, accessTokenTTL.Seconds(), asOf,
+	)
+	if err != nil {
+		fmt.Printf("Failed to list revoked sessions: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list revoked sessions"})
+		return
+	}
+	defer rows.Close()
+
+	sessions := []RevokedSession{}
+	for rows.Next() {
+		var session RevokedSession
+		if err := rows.Scan(&session.SessionId, &session.Until); err != nil {
+			fmt.Printf("Failed to scan session: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list revoked sessions"})
+			return
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list revoked sessions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions, "as_of": asOf.Add(-revokedSessionsOverlap)})
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSubscriptionsLimit = 20
+	maxSubscriptionsLimit     = 100
+)
+
+type SubscriptionUser struct {
+	UserId     string    `json:"user_id"`
+	Login      string    `json:"login"`
+	Name       string    `json:"name"`
+	Surname    string    `json:"surname"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// encodeSubscriptionsCursor packs the position of the last returned
+// subscription into an opaque string.
+func encodeSubscriptionsCursor(createdAt time.Time, userId string) string {
+	raw := strconv.FormatInt(createdAt.UnixNano(), 10) + ":" + userId
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSubscriptionsCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	nanos, userId, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("malformed cursor")
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return time.Unix(0, n).UTC(), userId, nil
+}
+
+func (app *App) userExists(userID string) (bool, error) {
+	var exists bool
+	err := app.DB.QueryRow(context.Background(), "SELECT EXISTS (SELECT 1 FROM users WHERE user_id=$1)", userID).Scan(&exists)
+	return exists, err
+}
+
+func (app *App) Follow(c *gin.Context) {
+	// Get user ID from header
+	userID := c.GetHeader("X-User-Id")
+	if userID == "" {
+		fmt.Println("Failed to get user ID from header")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	targetID := c.Param("id")
+	if targetID == userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Can not follow yourself"})
+		return
+	}
+
+	exists, err := app.userExists(targetID)
+	if err != nil {
+		fmt.Printf("Failed to find user with id %s: %v\n", targetID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow user"})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	_, err = app.DB.Exec(
+		context.Background(),
+		"INSERT INTO subscriptions (subscriber_id, subscribed_to_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		userID, targetID,
+	)
+	if err != nil {
+		fmt.Printf("Failed to follow user: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to follow user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"following": true})
+}
+
+func (app *App) Unfollow(c *gin.Context) {
+	// Get user ID from header
+	userID := c.GetHeader("X-User-Id")
+	if userID == "" {
+		fmt.Println("Failed to get user ID from header")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	_, err := app.DB.Exec(
+		context.Background(),
+		"DELETE FROM subscriptions WHERE subscriber_id=$1 AND subscribed_to_id=$2",
+		userID, c.Param("id"),
+	)
+	if err != nil {
+		fmt.Printf("Failed to unfollow user: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unfollow user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"following": false})
+}
+
+// IsFollowing reports whether user :id follows user :target_id.
+func (app *App) IsFollowing(c *gin.Context) {
+	var following bool
+	err := app.DB.QueryRow(
+		context.Background(),
+		"SELECT EXISTS (SELECT 1 FROM subscriptions WHERE subscriber_id=$1 AND subscribed_to_id=$2)",
+		c.Param("id"), c.Param("target_id"),
+	).Scan(&following)
+	if err != nil {
+		fmt.Printf("Failed to check subscription: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check subscription"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"following": following})
+}
+
+type FollowCounts struct {
+	FollowersCount int `json:"followers_count"`
+	FollowingCount int `json:"following_count"`
+}
+
+func (app *App) followCounts(userID string) (FollowCounts, error) {
+	var counts FollowCounts
+	err := app.DB.QueryRow(
+		context.Background(),
+		`SELECT
+			(SELECT COUNT(*) FROM subscriptions WHERE subscribed_to_id=$1),
+			(SELECT COUNT(*) FROM subscriptions WHERE subscriber_id=$1)`,
+		userID,
+	).Scan(&counts.FollowersCount, &counts.FollowingCount)
+	return counts, err
+}
+
+func (app *App) GetFollowCounts(c *gin.Context) {
+	userID := c.Param("id")
+	exists, err := app.userExists(userID)
+	if err != nil || !exists {
+		fmt.Printf("Failed to find user with id %s: %v\n", userID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	counts, err := app.followCounts(userID)
+	if err != nil {
+		fmt.Printf("Failed to count subscriptions: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count subscriptions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// ListFollowingIds returns the ids of every user :id follows. It is meant for
+// other services building per-user views, such as the posts feed, and is not
+// exposed through the gateway.
+func (app *App) ListFollowingIds(c *gin.Context) {
+	rows, err := app.DB.Query(
+		context.Background(),
+		"SELECT subscribed_to_id FROM subscriptions WHERE subscriber_id=$1",
+		c.Param("id"),
+	)
+	if err != nil {
+		fmt.Printf("Failed to list subscriptions: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+		return
+	}
+	defer rows.Close()
+
+	userIds := []string{}
+	for rows.Next() {
+		var userId string
+		if err := rows.Scan(&userId); err != nil {
+			fmt.Printf("Failed to scan user id: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+			return
+		}
+		userIds = append(userIds, userId)
+	}
+	if err := rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_ids": userIds})
+}
+
+func (app *App) ListFollowers(c *gin.Context) {
+	app.listSubscriptions(c, "subscribed_to_id", "subscriber_id")
+}
+
+func (app *App) ListFollowing(c *gin.Context) {
+	app.listSubscriptions(c, "subscriber_id", "subscribed_to_id")
+}
+
+// listSubscriptions lists users on the other side of :id's subscriptions,
+// most recent first. ownColumn is the subscriptions column holding :id and
+// otherColumn the one holding the listed users.
+func (app *App) listSubscriptions(c *gin.Context, ownColumn string, otherColumn string) {
+	userID := c.Param("id")
+	exists, err := app.userExists(userID)
+	if err != nil || !exists {
+		fmt.Printf("Failed to find user with id %s: %v\n", userID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	limit := defaultSubscriptionsLimit
+	if limitParam := c.Query("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit format"})
+			return
+		}
+	}
+	limit = min(limit, maxSubscriptionsLimit)
+
+	var beforeCreatedAt *time.Time
+	var beforeUserID string
+	if cursor := c.Query("cursor"); cursor != "" {
+		createdAt, cursorUserID, err := decodeSubscriptionsCursor(cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		beforeCreatedAt, beforeUserID = &createdAt, cursorUserID
+	}
+
+	var totalCount int
+	err = app.DB.QueryRow(
+		context.Background(),
+		fmt.Sprintf("SELECT COUNT(*) FROM subscriptions WHERE %s=$1", ownColumn),
+		userID,
+	).Scan(&totalCount)
+	if err != nil {
+		fmt.Printf("Failed to count subscriptions: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count subscriptions"})
+		return
+	}
+
+	// Fetch one extra row to find out whether there is a next page.
+	query := fmt.Sprintf(
+		`SELECT u.user_id, u.login, u.name, u.surname, s.created_at
+		FROM subscriptions s JOIN users u ON u.user_id = s.%[2]s
+		WHERE s.%[1]s = $1 AND ($2::timestamp IS NULL OR (s.created_at, s.%[2]s) < ($2, $3))
+		ORDER BY s.created_at DESC, s.%[2]s DESC
+		LIMIT $4`,
+		ownColumn, otherColumn,
+	)
+	rows, err := app.DB.Query(context.Background(), query, userID, beforeCreatedAt, beforeUserID, limit+1)
+	if err != nil {
+		fmt.Printf("Failed to list subscriptions: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+		return
+	}
+	defer rows.Close()
+
+	users := []SubscriptionUser{}
+	for rows.Next() {
+		var user SubscriptionUser
+		if err := rows.Scan(&user.UserId, &user.Login, &user.Name, &user.Surname, &user.FollowedAt); err != nil {
+			fmt.Printf("Failed to scan user: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+			return
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list subscriptions"})
+		return
+	}
+
+	nextCursor := ""
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[len(users)-1]
+		nextCursor = encodeSubscriptionsCursor(last.FollowedAt, last.UserId)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users, "total_count": totalCount, "next_cursor": nextCursor})
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5"
+)
+
+// accessTokenTTL is kept short because access tokens are checked without a
+// round trip to the refresh token chain; clients renew them with /refresh.
+const accessTokenTTL = 15 * time.Minute
+
+type TokenPair struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	UserId       string `json:"user_id"`
+	SessionId    string `json:"session_id"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (app *App) issueAccessToken(userID string, sessionID string) (string, error) {
+	return app.Keys.Sign(jwt.MapClaims{
+		"user_id":    userID,
+		"session_id": sessionID,
+		"exp":        time.Now().Add(accessTokenTTL).Unix(),
+	})
+}
+
+// hashRefreshToken returns the form refresh tokens are stored in, so a leaked
+// database does not hand out usable tokens.
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// addRefreshToken generates a new opaque refresh token for the session and
+// stores its hash.
+func addRefreshToken(ctx context.Context, tx pgx.Tx, sessionID string) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(raw)
+	_, err := tx.Exec(ctx,
+		"INSERT INTO refresh_tokens (token_hash, session_id) VALUES ($1, $2)",
+		hashRefreshToken(refreshToken), sessionID,
+	)
+	return refreshToken, err
+}
+
+// issueTokens starts a new refresh token family for the session and returns
+// it together with a fresh access token.
+func (app *App) issueTokens(userID string, sessionID string) (TokenPair, error) {
+	ctx := context.Background()
+	tx, err := app.DB.Begin(ctx)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	refreshToken, err := addRefreshToken(ctx, tx, sessionID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	accessToken, err := app.issueAccessToken(userID, sessionID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(accessTokenTTL.Seconds()),
+		UserId:       userID,
+		SessionId:    sessionID,
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. Every refresh token can be used once: presenting a used one means it
+// leaked, so the whole family, i.e. the session, is revoked.
+func (app *App) Refresh(c *gin.Context) {
+	var refreshReq RefreshRequest
+	if err := c.ShouldBindJSON(&refreshReq); err != nil || refreshReq.RefreshToken == "" {
+		fmt.Printf("Failed to bind JSON: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	ctx := context.Background()
+	tx, err := app.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	var sessionID, userID string
+	var used, active bool
+	err = tx.QueryRow(ctx,
+		`SELECT rt.session_id, s.user_id, rt.used_at IS NOT NULL,
+			NOT s.terminated AND s.expires_at > CURRENT_TIMESTAMP
+		FROM refresh_tokens rt JOIN sessions s ON s.session_id = rt.session_id
+		WHERE rt.token_hash = $1
+		FOR UPDATE OF rt`,
+		hashRefreshToken(refreshReq.RefreshToken),
+	).Scan(&sessionID, &userID, &used, &active)
+	if err == pgx.ErrNoRows {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+	if err != nil {
+		fmt.Printf("Failed to find refresh token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	if used {
+		fmt.Printf("Refresh token reuse detected, revoking session %s\n", sessionID)
+		_, err = tx.Exec(ctx,
+			"UPDATE sessions SET terminated=TRUE, terminated_at=CURRENT_TIMESTAMP WHERE session_id=$1 AND NOT terminated",
+			sessionID,
+		)
+		if err == nil {
+			err = tx.Commit(ctx)
+		}
+		if err != nil {
+			fmt.Printf("Failed to revoke session: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token was already used"})
+		return
+	}
+	if !active {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Session is not active"})
+		return
+	}
+
+	_, err = tx.Exec(ctx,
+		"UPDATE refresh_tokens SET used_at=CURRENT_TIMESTAMP WHERE token_hash=$1",
+		hashRefreshToken(refreshReq.RefreshToken),
+	)
+	if err != nil {
+		fmt.Printf("Failed to mark refresh token as used: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+	refreshToken, err := addRefreshToken(ctx, tx, sessionID)
+	if err != nil {
+		fmt.Printf("Failed to create refresh token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+	accessToken, err := app.issueAccessToken(userID, sessionID)
+	if err != nil {
+		fmt.Printf("Failed to generate token: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, TokenPair{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(accessTokenTTL.Seconds()),
+		UserId:       userID,
+		SessionId:    sessionID,
+	})
+}
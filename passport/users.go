@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/passport"
+)
+
+// The operations below are shared by the HTTP handlers and the gRPC server.
+// They report the failures callers are expected to handle with these errors.
+var (
+	errUserExists         = errors.New("user already exists")
+	errUserNotFound       = errors.New("user not found")
+	errInvalidCredentials = errors.New("invalid login or password")
+	errInvalidToken       = errors.New("invalid token")
+	errSessionNotActive   = errors.New("session is not active")
+	errInvalidProfile     = errors.New("invalid profile")
+)
+
+const userColumns = `user_id, login, email, name, surname, date_of_birth, phone_number, created_at, updated_at, display_name, avatar_url, bio`
+
+func scanUser(row pgx.Row) (*passport.User, error) {
+	var user passport.User
+	var dateOfBirth pgtype.Date
+	var createdAt, updatedAt time.Time
+	err := row.Scan(&user.UserId, &user.Login, &user.Email, &user.Name, &user.Surname, &dateOfBirth, &user.PhoneNumber, &createdAt, &updatedAt,
+		&user.DisplayName, &user.AvatarUrl, &user.Bio)
+	if err != nil {
+		return nil, err
+	}
+	user.DateOfBirth = dateOfBirth.Time.Format("2006-01-02")
+	user.CreatedAt = timestamppb.New(createdAt)
+	user.UpdatedAt = timestamppb.New(updatedAt)
+	return &user, nil
+}
+
+func (app *App) registerUser(user RegisterRequest) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %v", err)
+	}
+	now := time.Now()
+	var userID string
+	err = app.DB.QueryRow(
+		context.Background(),
+		"INSERT INTO users (login, email, hashed_password, name, surname, date_of_birth, phone_number, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING user_id",
+		user.Login, user.Email, string(hashedPassword), user.Name, user.Surname, user.DateOfBirth, user.PhoneNumber, now, now,
+	).Scan(&userID)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return "", errUserExists
+	}
+	return userID, err
+}
+
+// login checks the credentials and opens a session for them.
+func (app *App) login(login string, password string, userAgent string, ip string) (TokenPair, error) {
+	var hashedPassword string
+	var userID string
+	err := app.DB.QueryRow(context.Background(), "SELECT hashed_password, user_id FROM users WHERE login=$1", login).Scan(&hashedPassword, &userID)
+	if err == pgx.ErrNoRows {
+		fmt.Printf("Failed to find user with login %s\n", login)
+		return TokenPair{}, errInvalidCredentials
+	}
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("failed to find user: %v", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)); err != nil {
+		fmt.Printf("Failed to compare passwords: %v\n", err)
+		return TokenPair{}, errInvalidCredentials
+	}
+
+	// Every login opens a session, so the token can be revoked before it expires.
+	sessionID, err := app.createSession(userID, userAgent, ip)
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("failed to create session: %v", err)
+	}
+	tokens, err := app.issueTokens(userID, sessionID)
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("failed to generate token: %v", err)
+	}
+	return tokens, nil
+}
+
+// validateToken checks the token signature and expiry and that its session
+// is still active.
+func (app *App) validateToken(tokenString string) (string, string, error) {
+	token, err := jwt.Parse(tokenString, app.Keys.Keyfunc)
+	if err != nil {
+		fmt.Printf("Failed to parse token: %v\n", err)
+		return "", "", errInvalidToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		fmt.Println("Failed to validate token")
+		return "", "", errInvalidToken
+	}
+
+	userID, _ := claims["user_id"].(string)
+	sessionID, _ := claims["session_id"].(string)
+	if userID == "" || sessionID == "" {
+		fmt.Println("Token has no user or session ID")
+		return "", "", errInvalidToken
+	}
+	active, err := app.isSessionActive(sessionID, userID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to check session: %v", err)
+	}
+	if !active {
+		fmt.Printf("Session %s is not active\n", sessionID)
+		return "", "", errSessionNotActive
+	}
+	return userID, sessionID, nil
+}
+
+func (app *App) getUser(userID string) (*passport.User, error) {
+	user, err := scanUser(app.DB.QueryRow(context.Background(), "SELECT "+userColumns+" FROM users WHERE user_id=$1", userID))
+	if err == pgx.ErrNoRows {
+		return nil, errUserNotFound
+	}
+	return user, err
+}
+
+// getUsers returns the users among userIDs that exist, in no particular order.
+func (app *App) getUsers(userIDs []string) ([]*passport.User, error) {
+	rows, err := app.DB.Query(context.Background(), "SELECT "+userColumns+" FROM users WHERE user_id = ANY($1)", userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*passport.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (app *App) updateUser(userID string, user UpdateUserRequest) error {
+	if err := validateProfile(user); err != nil {
+		return err
+	}
+	tag, err := app.DB.Exec(
+		context.Background(),
+		"UPDATE users SET email=$1, name=$2, surname=$3, date_of_birth=$4, phone_number=$5, display_name=$6, avatar_url=$7, bio=$8, updated_at=$9 WHERE user_id=$10",
+		user.Email, user.Name, user.Surname, user.DateOfBirth, user.PhoneNumber, user.DisplayName, user.AvatarUrl, user.Bio, time.Now(), userID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return errUserExists
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errUserNotFound
+	}
+	return nil
+}
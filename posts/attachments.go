@@ -0,0 +1,377 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	maxAttachmentsPerPost = 10
+	maxFilenameLength     = 255
+	// defaultAttachmentTTL is how long unattached attachments and unfinished
+	// uploads are kept.
+	defaultAttachmentTTL             = 24 * time.Hour
+	defaultAttachmentCollectInterval = time.Hour
+	// maxReferencedBlobKeys bounds the keys of one GetReferencedBlobKeys call.
+	maxReferencedBlobKeys = 1000
+)
+
+const attachmentColumns = `attachment_id, owner_id, COALESCE(post_id, ''), filename, mime_type, size, width, height, checksum, storage_key, thumbnail_key, created_at`
+
+func scanAttachment(row pgx.Row) (*posts.Attachment, error) {
+	var attachment posts.Attachment
+	var createdAt time.Time
+	err := row.Scan(&attachment.AttachmentId, &attachment.OwnerId, &attachment.PostId, &attachment.Filename, &attachment.MimeType,
+		&attachment.Size, &attachment.Width, &attachment.Height, &attachment.Checksum, &attachment.StorageKey, &attachment.ThumbnailKey, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	attachment.CreatedAt = timestamppb.New(createdAt)
+	return &attachment, nil
+}
+
+const uploadColumns = `upload_id, owner_id, filename, size, received, checksum, chunk_keys, created_at`
+
+func scanUpload(row pgx.Row) (*posts.Upload, error) {
+	var upload posts.Upload
+	var createdAt time.Time
+	err := row.Scan(&upload.UploadId, &upload.OwnerId, &upload.Filename, &upload.Size, &upload.Received, &upload.Checksum, &upload.ChunkKeys, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	upload.CreatedAt = timestamppb.New(createdAt)
+	return &upload, nil
+}
+
+// checkAttachmentIds rejects duplicate ids and too many attachments.
+func checkAttachmentIds(ids []string) error {
+	if len(ids) > maxAttachmentsPerPost {
+		return invalidArgument("attachment_ids", fmt.Sprintf("a post can have at most %d attachments", maxAttachmentsPerPost))
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return invalidArgument("attachment_ids", fmt.Sprintf("attachment %s is listed twice", id))
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// checkChecksum accepts hex-encoded SHA-256 sums; empty is accepted only if
+// optional.
+func checkChecksum(checksum string, optional bool) error {
+	if checksum == "" && optional {
+		return nil
+	}
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != 32 {
+		return invalidArgument("checksum", "must be a hex-encoded SHA-256 sum")
+	}
+	return nil
+}
+
+// setPostAttachments replaces the attachments of a post. New attachments must
+// be unattached uploads of ownerId; the row locks taken by the update make
+// sure an attachment ends up in only one post.
+func setPostAttachments(ctx context.Context, tx pgx.Tx, postId string, ownerId string, ids []string) error {
+	_, err := tx.Exec(ctx, `UPDATE attachments SET post_id = NULL, detached_at = CURRENT_TIMESTAMP WHERE post_id = $1`, postId)
+	if err != nil {
+		fmt.Printf("Failed to detach attachments: %v\n", err)
+		return dbError("failed to detach attachments", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `UPDATE attachments a SET post_id = $1, position = ids.position
+			  FROM unnest($2::text[]) WITH ORDINALITY AS ids(attachment_id, position)
+			  WHERE a.attachment_id = ids.attachment_id AND a.owner_id = $3 AND a.post_id IS NULL`
+	tag, err := tx.Exec(ctx, query, postId, ids, ownerId)
+	if err != nil {
+		fmt.Printf("Failed to attach attachments: %v\n", err)
+		return dbError("failed to attach attachments", err)
+	}
+	if tag.RowsAffected() != int64(len(ids)) {
+		return invalidArgument("attachment_ids", "attachments must be your own uploads not attached to another post")
+	}
+	return nil
+}
+
+// fillAttachments sets the attachments of every post in postsList using a
+// single query.
+func (s *PostServiceServer) fillAttachments(ctx context.Context, postsList []*posts.Post) error {
+	if len(postsList) == 0 {
+		return nil
+	}
+	byId := make(map[string]*posts.Post, len(postsList))
+	postIds := make([]string, 0, len(postsList))
+	for _, post := range postsList {
+		post.Attachments = []*posts.Attachment{}
+		byId[post.PostId] = post
+		postIds = append(postIds, post.PostId)
+	}
+
+	query := `SELECT ` + attachmentColumns + ` FROM attachments
+			  WHERE post_id = ANY($1)
+			  ORDER BY position`
+	rows, err := s.App.DB.Query(ctx, query, postIds)
+	if err != nil {
+		fmt.Printf("Failed to fetch attachments: %v\n", err)
+		return dbError("failed to fetch attachments", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			fmt.Printf("Failed to scan attachment: %v\n", err)
+			return dbError("failed to scan attachment", err)
+		}
+		post := byId[attachment.PostId]
+		post.Attachments = append(post.Attachments, attachment)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return dbError("error iterating over rows", err)
+	}
+	return nil
+}
+
+func (s *PostServiceServer) CreateAttachment(ctx context.Context, req *posts.CreateAttachmentRequest) (*posts.CreateAttachmentResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Filename) > maxFilenameLength {
+		return nil, invalidArgument("filename", fmt.Sprintf("must be at most %d characters", maxFilenameLength))
+	}
+	if req.MimeType == "" {
+		return nil, invalidArgument("mime_type", "must not be empty")
+	}
+	if req.Size <= 0 {
+		return nil, invalidArgument("size", "must be positive")
+	}
+	if req.StorageKey == "" {
+		return nil, invalidArgument("storage_key", "must not be empty")
+	}
+	if err := checkChecksum(req.Checksum, false); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if req.UploadId != "" {
+		query := `DELETE FROM uploads WHERE upload_id = $1 AND owner_id = $2 AND received = size AND size = $3`
+		tag, err := tx.Exec(ctx, query, req.UploadId, actorUserId, req.Size)
+		if err != nil {
+			fmt.Printf("Failed to finish upload: %v\n", err)
+			return nil, dbError("failed to finish upload", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return nil, notFound("complete upload", req.UploadId)
+		}
+	}
+
+	query := `INSERT INTO attachments (owner_id, filename, mime_type, size, width, height, checksum, storage_key, thumbnail_key)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			  RETURNING ` + attachmentColumns
+	attachment, err := scanAttachment(tx.QueryRow(ctx, query, actorUserId, req.Filename, req.MimeType, req.Size,
+		req.Width, req.Height, req.Checksum, req.StorageKey, req.ThumbnailKey))
+	if err != nil {
+		fmt.Printf("Failed to create attachment: %v\n", err)
+		return nil, dbError("failed to create attachment", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	return &posts.CreateAttachmentResponse{Attachment: attachment}, nil
+}
+
+func (s *PostServiceServer) GetAttachment(ctx context.Context, req *posts.GetAttachmentRequest) (*posts.GetAttachmentResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attachments are as visible as their post; unattached ones only to the
+	// owner.
+	query := `SELECT ` + attachmentColumns + ` FROM attachments
+			  WHERE attachment_id = $1
+			    AND (owner_id = $2 OR EXISTS (
+			      SELECT 1 FROM posts p WHERE p.post_id = attachments.post_id AND ` + visiblePostCondition("p", "$2") + `))`
+	attachment, err := scanAttachment(s.App.DB.QueryRow(ctx, query, req.AttachmentId, actorUserId))
+	if err == pgx.ErrNoRows {
+		return nil, notFound("attachment", req.AttachmentId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch attachment: %v\n", err)
+		return nil, dbError("failed to fetch attachment", err)
+	}
+
+	return &posts.GetAttachmentResponse{Attachment: attachment}, nil
+}
+
+func (s *PostServiceServer) CreateUpload(ctx context.Context, req *posts.CreateUploadRequest) (*posts.CreateUploadResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Filename) > maxFilenameLength {
+		return nil, invalidArgument("filename", fmt.Sprintf("must be at most %d characters", maxFilenameLength))
+	}
+	if req.Size <= 0 {
+		return nil, invalidArgument("size", "must be positive")
+	}
+	if err := checkChecksum(req.Checksum, true); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO uploads (owner_id, filename, size, checksum) VALUES ($1, $2, $3, $4) RETURNING ` + uploadColumns
+	upload, err := scanUpload(s.App.DB.QueryRow(ctx, query, actorUserId, req.Filename, req.Size, req.Checksum))
+	if err != nil {
+		fmt.Printf("Failed to create upload: %v\n", err)
+		return nil, dbError("failed to create upload", err)
+	}
+
+	return &posts.CreateUploadResponse{Upload: upload}, nil
+}
+
+func (s *PostServiceServer) GetUpload(ctx context.Context, req *posts.GetUploadRequest) (*posts.GetUploadResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE upload_id = $1 AND owner_id = $2`
+	upload, err := scanUpload(s.App.DB.QueryRow(ctx, query, req.UploadId, actorUserId))
+	if err == pgx.ErrNoRows {
+		return nil, notFound("upload", req.UploadId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch upload: %v\n", err)
+		return nil, dbError("failed to fetch upload", err)
+	}
+
+	return &posts.GetUploadResponse{Upload: upload}, nil
+}
+
+func (s *PostServiceServer) AppendUploadChunk(ctx context.Context, req *posts.AppendUploadChunkRequest) (*posts.AppendUploadChunkResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Size <= 0 {
+		return nil, invalidArgument("size", "must be positive")
+	}
+	if req.StorageKey == "" {
+		return nil, invalidArgument("storage_key", "must not be empty")
+	}
+
+	// The offset check makes concurrent or repeated chunks fail instead of
+	// being recorded twice.
+	query := `UPDATE uploads SET received = received + $3, chunk_keys = array_append(chunk_keys, $4),
+			  last_chunk_at = CURRENT_TIMESTAMP
+			  WHERE upload_id = $1 AND owner_id = $5 AND received = $2 AND received + $3 <= size
+			  RETURNING ` + uploadColumns
+	upload, err := scanUpload(s.App.DB.QueryRow(ctx, query, req.UploadId, req.Offset, req.Size, req.StorageKey, actorUserId))
+	if err == pgx.ErrNoRows {
+		current, err := s.GetUpload(ctx, &posts.GetUploadRequest{UploadId: req.UploadId})
+		if err != nil {
+			return nil, err
+		}
+		if current.Upload.Received != req.Offset {
+			return nil, aborted("UPLOAD_OFFSET_MISMATCH", fmt.Sprintf("upload continues at offset %d", current.Upload.Received))
+		}
+		return nil, invalidArgument("size", fmt.Sprintf("chunk ends past the upload size %d", current.Upload.Size))
+	}
+	if err != nil {
+		fmt.Printf("Failed to append upload chunk: %v\n", err)
+		return nil, dbError("failed to append upload chunk", err)
+	}
+
+	return &posts.AppendUploadChunkResponse{Upload: upload}, nil
+}
+
+func (s *PostServiceServer) GetReferencedBlobKeys(ctx context.Context, req *posts.GetReferencedBlobKeysRequest) (*posts.GetReferencedBlobKeysResponse, error) {
+	if len(req.StorageKeys) > maxReferencedBlobKeys {
+		return nil, invalidArgument("storage_keys", fmt.Sprintf("at most %d keys can be checked at once", maxReferencedBlobKeys))
+	}
+
+	query := `SELECT storage_key FROM attachments WHERE storage_key = ANY($1)
+			  UNION
+			  SELECT thumbnail_key FROM attachments WHERE thumbnail_key = ANY($1)
+			  UNION
+			  SELECT k FROM uploads, unnest(chunk_keys) AS k WHERE chunk_keys && $1 AND k = ANY($1)`
+	rows, err := s.App.DB.Query(ctx, query, req.StorageKeys)
+	if err != nil {
+		fmt.Printf("Failed to fetch referenced blob keys: %v\n", err)
+		return nil, dbError("failed to fetch referenced blob keys", err)
+	}
+	keys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		fmt.Printf("Failed to scan blob key: %v\n", err)
+		return nil, dbError("failed to scan blob key", err)
+	}
+
+	return &posts.GetReferencedBlobKeysResponse{StorageKeys: keys}, nil
+}
+
+// AttachmentCollector removes attachments that have not been on a post for
+// TTL and uploads that have received no chunk for TTL. Their blobs are then
+// unreferenced, and the gateway deletes them when it sweeps its blob store.
+type AttachmentCollector struct {
+	DB       *pgxpool.Pool
+	TTL      time.Duration
+	Interval time.Duration
+}
+
+func (c *AttachmentCollector) Run(ctx context.Context) {
+	for {
+		if err := c.collect(ctx); err != nil {
+			fmt.Printf("Failed to collect attachments: %v\n", err)
+		}
+		select {
+		case <-time.After(c.Interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect deletes the expired rows. Attaching an attachment locks its row
+// and requires post_id IS NULL, so an attachment is either attached or
+// deleted, never both.
+func (c *AttachmentCollector) collect(ctx context.Context) error {
+	query := `DELETE FROM attachments
+			  WHERE post_id IS NULL AND detached_at <= CURRENT_TIMESTAMP - make_interval(secs => $1)`
+	attachments, err := c.DB.Exec(ctx, query, c.TTL.Seconds())
+	if err != nil {
+		return err
+	}
+	query = `DELETE FROM uploads WHERE last_chunk_at <= CURRENT_TIMESTAMP - make_interval(secs => $1)`
+	uploads, err := c.DB.Exec(ctx, query, c.TTL.Seconds())
+	if err != nil {
+		return err
+	}
+	if attachments.RowsAffected() > 0 || uploads.RowsAffected() > 0 {
+		fmt.Printf("Removed %d unattached attachments and %d abandoned uploads\n", attachments.RowsAffected(), uploads.RowsAffected())
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/events"
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	defaultCommentsLimit = 20
+	maxCommentsLimit     = 100
+)
+
+const commentColumns = `c.comment_id, c.post_id, c.parent_comment_id, c.creator_id, c.content, c.created_at, c.updated_at,
+	(SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.comment_id)`
+
+func scanComment(row pgx.Row) (*posts.Comment, error) {
+	var comment posts.Comment
+	var parentCommentId *string
+	var createdAt, updatedAt time.Time
+	err := row.Scan(&comment.CommentId, &comment.PostId, &parentCommentId, &comment.CreatorId, &comment.Content, &createdAt, &updatedAt, &comment.ReplyCount)
+	if err != nil {
+		return nil, err
+	}
+	if parentCommentId != nil {
+		comment.ParentCommentId = *parentCommentId
+	}
+	comment.CreatedAt = timestamppb.New(createdAt)
+	comment.UpdatedAt = timestamppb.New(updatedAt)
+	return &comment, nil
+}
+
+func (s *PostServiceServer) CreateComment(ctx context.Context, req *posts.CreateCommentRequest) (*posts.CreateCommentResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Content == "" {
+		return nil, invalidArgument("content", "comment content is required")
+	}
+	if err := s.checkPostAccess(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
+	}
+
+	var parentCommentId *string
+	if req.ParentCommentId != "" {
+		var parentPostId string
+		query := `SELECT post_id FROM comments WHERE comment_id = $1`
+		err := s.App.DB.QueryRow(ctx, query, req.ParentCommentId).Scan(&parentPostId)
+		if err == pgx.ErrNoRows {
+			return nil, notFound("comment", req.ParentCommentId)
+		}
+		if err != nil {
+			fmt.Printf("Failed to fetch parent comment: %v\n", err)
+			return nil, dbError("failed to fetch parent comment", err)
+		}
+		if parentPostId != req.PostId {
+			fmt.Printf("Parent comment belongs to another post\n")
+			return nil, invalidArgument("parent_comment_id", "parent comment belongs to another post")
+		}
+		parentCommentId = &req.ParentCommentId
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO comments (post_id, parent_comment_id, creator_id, content) VALUES ($1, $2, $3, $4)
+			  RETURNING comment_id, created_at, updated_at`
+	row := tx.QueryRow(ctx, query, req.PostId, parentCommentId, actorUserId, req.Content)
+
+	comment := posts.Comment{
+		PostId:          req.PostId,
+		ParentCommentId: req.ParentCommentId,
+		CreatorId:       actorUserId,
+		Content:         req.Content,
+	}
+	var createdAt, updatedAt time.Time
+	err = row.Scan(&comment.CommentId, &createdAt, &updatedAt)
+	if err != nil {
+		fmt.Printf("Failed to create comment: %v\n", err)
+		return nil, dbError("failed to create comment", err)
+	}
+	err = addEvent(ctx, tx, req.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_CommentCreated{CommentCreated: &events.CommentCreated{
+			CommentId:       comment.CommentId,
+			ParentCommentId: req.ParentCommentId,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+	comment.CreatedAt = timestamppb.New(createdAt)
+	comment.UpdatedAt = timestamppb.New(updatedAt)
+
+	return &posts.CreateCommentResponse{Comment: &comment}, nil
+}
+
+func (s *PostServiceServer) UpdateComment(ctx context.Context, req *posts.UpdateCommentRequest) (*posts.UpdateCommentResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Content == "" {
+		return nil, invalidArgument("content", "comment content is required")
+	}
+
+	query := `SELECT creator_id FROM comments WHERE comment_id = $1 AND post_id = $2`
+	var creatorId string
+	err = s.App.DB.QueryRow(ctx, query, req.CommentId, req.PostId).Scan(&creatorId)
+	if err == pgx.ErrNoRows {
+		return nil, notFound("comment", req.CommentId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch comment: %v\n", err)
+		return nil, dbError("failed to fetch comment", err)
+	}
+	if actorUserId != creatorId {
+		fmt.Printf("Unauthorized: actor does not match comment creator\n")
+		return nil, permissionDenied("NOT_COMMENT_CREATOR", "actor does not match comment creator")
+	}
+	// The post may have turned private since the comment was written.
+	if err := s.checkPostAccess(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	query = `UPDATE comments c SET content = $1 WHERE c.comment_id = $2 RETURNING ` + commentColumns
+	comment, err := scanComment(tx.QueryRow(ctx, query, req.Content, req.CommentId))
+	if err == pgx.ErrNoRows {
+		return nil, notFound("comment", req.CommentId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to update comment: %v\n", err)
+		return nil, dbError("failed to update comment", err)
+	}
+	err = addEvent(ctx, tx, req.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_CommentUpdated{CommentUpdated: &events.CommentUpdated{CommentId: req.CommentId}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	return &posts.UpdateCommentResponse{Comment: comment}, nil
+}
+
+func (s *PostServiceServer) DeleteComment(ctx context.Context, req *posts.DeleteCommentRequest) (*posts.DeleteCommentResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Comments can be removed by their author or by the author of the post.
+	query := `SELECT c.creator_id, p.creator_id FROM comments c JOIN posts p ON p.post_id = c.post_id
+			  WHERE c.comment_id = $1 AND c.post_id = $2 AND p.deleted_at IS NULL`
+	var commentCreatorId, postCreatorId string
+	err = s.App.DB.QueryRow(ctx, query, req.CommentId, req.PostId).Scan(&commentCreatorId, &postCreatorId)
+	if err == pgx.ErrNoRows {
+		return nil, notFound("comment", req.CommentId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch comment: %v\n", err)
+		return nil, dbError("failed to fetch comment", err)
+	}
+	if actorUserId != commentCreatorId && actorUserId != postCreatorId {
+		fmt.Printf("Unauthorized: actor can not delete this comment\n")
+		return nil, permissionDenied("NOT_COMMENT_OR_POST_CREATOR", "actor can not delete this comment")
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	// Replies are removed by ON DELETE CASCADE; they are counted first so the
+	// event reports how many comments went away.
+	query = `WITH RECURSIVE thread AS (
+				 SELECT comment_id FROM comments WHERE comment_id = $1
+				 UNION ALL
+				 SELECT r.comment_id FROM comments r JOIN thread t ON r.parent_comment_id = t.comment_id
+			 )
+			 SELECT COUNT(*) FROM thread`
+	var deletedCount int32
+	err = tx.QueryRow(ctx, query, req.CommentId).Scan(&deletedCount)
+	if err != nil {
+		fmt.Printf("Failed to count replies: %v\n", err)
+		return nil, dbError("failed to count replies", err)
+	}
+
+	query = `DELETE FROM comments WHERE comment_id = $1`
+	tag, err := tx.Exec(ctx, query, req.CommentId)
+	if err != nil {
+		fmt.Printf("Failed to delete comment: %v\n", err)
+		return nil, dbError("failed to delete comment", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, notFound("comment", req.CommentId)
+	}
+	err = addEvent(ctx, tx, req.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_CommentDeleted{CommentDeleted: &events.CommentDeleted{
+			CommentId:    req.CommentId,
+			DeletedCount: deletedCount,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	return &posts.DeleteCommentResponse{Success: true}, nil
+}
+
+func (s *PostServiceServer) ListComments(ctx context.Context, req *posts.ListCommentsRequest) (*posts.ListCommentsResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPostAccess(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultCommentsLimit
+	}
+	if limit > maxCommentsLimit {
+		limit = maxCommentsLimit
+	}
+
+	// The zero cursor position sorts before every comment.
+	afterCreatedAt := time.Unix(0, 0).UTC()
+	afterCommentId := ""
+	if req.Cursor != "" {
+		var err error
+		afterCreatedAt, afterCommentId, err = decodeCursor(req.Cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			return nil, invalidArgument("cursor", err.Error())
+		}
+	}
+
+	// Fetch one extra row to find out whether there is a next page.
+	query := `SELECT ` + commentColumns + `
+			  FROM comments c
+			  WHERE c.post_id = $1
+			    AND c.parent_comment_id IS NOT DISTINCT FROM NULLIF($2, '')
+			    AND (c.created_at, c.comment_id) > ($3, $4)
+			  ORDER BY c.created_at ASC, c.comment_id ASC
+			  LIMIT $5`
+	rows, err := s.App.DB.Query(ctx, query, req.PostId, req.ParentCommentId, afterCreatedAt, afterCommentId, limit+1)
+	if err != nil {
+		fmt.Printf("Failed to fetch comments: %v\n", err)
+		return nil, dbError("failed to fetch comments", err)
+	}
+	defer rows.Close()
+
+	comments := []*posts.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			fmt.Printf("Failed to scan comment: %v\n", err)
+			return nil, dbError("failed to scan comment", err)
+		}
+		comments = append(comments, comment)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	var nextCursor string
+	if len(comments) > int(limit) {
+		comments = comments[:limit]
+		last := comments[len(comments)-1]
+		nextCursor = encodeCursor(last.CreatedAt.AsTime(), last.CommentId)
+	}
+
+	return &posts.ListCommentsResponse{Comments: comments, NextCursor: nextCursor}, nil
+}
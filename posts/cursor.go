@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// encodeCursor packs the (timestamp, id) position of the last returned row
+// into an opaque string.
+func encodeCursor(at time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(formatPosition(at, id)))
+}
+
+func decodeCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return parsePosition(string(raw))
+}
+
+// encodePageToken is like encodeCursor but also records whether the token
+// pages forward (after the position) or backward (before it).
+func encodePageToken(backward bool, at time.Time, id string) string {
+	direction := "next"
+	if backward {
+		direction = "prev"
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(direction + ":" + formatPosition(at, id)))
+}
+
+func decodePageToken(token string) (bool, time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return false, time.Time{}, "", err
+	}
+	direction, position, ok := strings.Cut(string(raw), ":")
+	if !ok || (direction != "next" && direction != "prev") {
+		return false, time.Time{}, "", fmt.Errorf("malformed page token")
+	}
+	at, id, err := parsePosition(position)
+	if err != nil {
+		return false, time.Time{}, "", err
+	}
+	return direction == "prev", at, id, nil
+}
+
+func formatPosition(at time.Time, id string) string {
+	return strconv.FormatInt(at.UnixNano(), 10) + ":" + id
+}
+
+func parsePosition(position string) (time.Time, string, error) {
+	nanos, id, ok := strings.Cut(position, ":")
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("malformed cursor")
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return time.Unix(0, n).UTC(), id, nil
+}
+
+// encodeSearchCursor packs the (rank, id) position of the last search result
+// into an opaque string.
+func encodeSearchCursor(rank float32, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatFloat(float64(rank), 'g', -1, 32) + ":" + id))
+}
+
+func decodeSearchCursor(cursor string) (float32, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", err
+	}
+	rank, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return 0, "", fmt.Errorf("malformed cursor")
+	}
+	r, err := strconv.ParseFloat(rank, 32)
+	if err != nil {
+		return 0, "", err
+	}
+	return float32(r), id, nil
+}
+
+// encodeRevisionCursor packs the number of the last returned revision into an
+// opaque string.
+func encodeRevisionCursor(revision int32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(int64(revision), 10)))
+}
+
+func decodeRevisionCursor(cursor string) (int32, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	revision, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(revision), nil
+}
@@ -1,4 +1,4 @@
-DROP TABLE IF EXISTS posts, posts_tags, tags CASCADE;
+DROP TABLE IF EXISTS posts, post_revisions, posts_tags, tags, comments, post_likes, attachments, uploads, outbox CASCADE;
 
 CREATE TABLE posts (
     post_id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -7,9 +7,31 @@ CREATE TABLE posts (
     creator_id VARCHAR(36) NOT NULL,
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    is_private BOOLEAN DEFAULT FALSE
+    is_private BOOLEAN DEFAULT FALSE,
+    -- Number of the latest row in post_revisions.
+    revision INT NOT NULL DEFAULT 1,
+    -- Set when the post is moved to the trash; the purger removes the row
+    -- once the retention window passes.
+    deleted_at TIMESTAMP,
+    -- Drafts and scheduled posts are seen only by their creator. The
+    -- scheduler publishes scheduled posts once publish_at comes.
+    status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'scheduled', 'published')),
+    publish_at TIMESTAMP,
+    -- Text search configuration the post is indexed with. The service
+    -- reindexes posts with a different one on startup.
+    search_config REGCONFIG NOT NULL DEFAULT 'russian',
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector(search_config, title), 'A') ||
+        setweight(to_tsvector(search_config, description), 'B')
+    ) STORED
 );
 
+CREATE INDEX posts_search_vector_idx ON posts USING GIN (search_vector);
+CREATE INDEX posts_deleted_at_idx ON posts (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX posts_scheduled_idx ON posts (publish_at) WHERE status = 'scheduled';
+-- Listings and the feed order posts by publication time.
+CREATE INDEX posts_listed_at_idx ON posts ((COALESCE(publish_at, created_at)), post_id);
+
 -- Create a function to update the updated_at column
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
@@ -19,12 +41,26 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
--- Create a trigger for the posts table
+-- Create a trigger for the posts table. Reindexing and moving to or from the
+-- trash are not edits, so they keep updated_at.
 CREATE TRIGGER set_updated_at
 BEFORE UPDATE ON posts
 FOR EACH ROW
+WHEN (OLD.search_config = NEW.search_config AND OLD.deleted_at IS NOT DISTINCT FROM NEW.deleted_at)
 EXECUTE FUNCTION update_updated_at_column();
 
+-- Content of a post after each create and update, never changed.
+CREATE TABLE post_revisions (
+    post_id VARCHAR(36) NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    is_private BOOLEAN NOT NULL,
+    editor_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, revision)
+);
+
 CREATE TABLE tags (
     id SERIAL PRIMARY KEY,
     name TEXT NOT NULL UNIQUE
@@ -35,3 +71,87 @@ CREATE TABLE posts_tags (
     tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
     PRIMARY KEY (post_id, tag_id)
 );
+
+CREATE INDEX posts_tags_tag_id_idx ON posts_tags (tag_id);
+
+CREATE TABLE comments (
+    comment_id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id VARCHAR(36) NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    parent_comment_id VARCHAR(36) REFERENCES comments(comment_id) ON DELETE CASCADE,
+    creator_id VARCHAR(36) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX comments_post_parent_idx ON comments (post_id, parent_comment_id, created_at, comment_id);
+
+CREATE TRIGGER set_comments_updated_at
+BEFORE UPDATE ON comments
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE post_likes (
+    post_id VARCHAR(36) NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL,
+    reaction_type VARCHAR(16) NOT NULL CHECK (reaction_type IN ('like', 'love', 'laugh', 'wow', 'sad', 'angry')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE INDEX post_likes_post_created_at_idx ON post_likes (post_id, created_at, user_id);
+
+-- Metadata of uploaded images and files; the content is in the gateway's
+-- blob store under storage_key. post_id is NULL until the owner attaches the
+-- attachment to a post.
+CREATE TABLE attachments (
+    attachment_id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id VARCHAR(36) NOT NULL,
+    post_id VARCHAR(36) REFERENCES posts(post_id) ON DELETE CASCADE,
+    -- Order of the attachment within its post.
+    position INT NOT NULL DEFAULT 0,
+    filename TEXT NOT NULL,
+    mime_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    width INT NOT NULL DEFAULT 0,
+    height INT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- When the attachment was created or last taken off a post. Attachments
+    -- left unattached for long are removed.
+    detached_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX attachments_post_idx ON attachments (post_id, position) WHERE post_id IS NOT NULL;
+CREATE INDEX attachments_detached_idx ON attachments (detached_at) WHERE post_id IS NULL;
+CREATE INDEX attachments_storage_key_idx ON attachments (storage_key);
+CREATE INDEX attachments_thumbnail_key_idx ON attachments (thumbnail_key);
+
+-- Chunked uploads in progress. Each received chunk is a blob listed in
+-- chunk_keys; the row is removed once the attachment is created, or when
+-- no chunk has arrived for a while and the upload is considered abandoned.
+CREATE TABLE uploads (
+    upload_id VARCHAR(36) PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id VARCHAR(36) NOT NULL,
+    filename TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    received BIGINT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64) NOT NULL DEFAULT '',
+    chunk_keys TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_chunk_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX uploads_last_chunk_at_idx ON uploads (last_chunk_at);
+CREATE INDEX uploads_chunk_keys_idx ON uploads USING GIN (chunk_keys);
+
+-- Events waiting to be published, written in the same transaction as the
+-- change they describe. payload is an encoded proto.events.PostEvent.
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    post_id VARCHAR(36) NOT NULL,
+    payload BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
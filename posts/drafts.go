@@ -0,0 +1,295 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"msg.i3cheese.ru/proto/events"
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	defaultSchedulerInterval = 10 * time.Second
+	publishBatchSize         = 100
+
+	defaultDraftsLimit = 20
+	maxDraftsLimit     = 100
+)
+
+// postStatusNames are the values of posts.status for each PostStatus.
+var postStatusNames = map[posts.PostStatus]string{
+	posts.PostStatus_POST_STATUS_PUBLISHED: "published",
+	posts.PostStatus_POST_STATUS_DRAFT:     "draft",
+	posts.PostStatus_POST_STATUS_SCHEDULED: "scheduled",
+}
+
+func postStatusFromName(name string) posts.PostStatus {
+	for status, statusName := range postStatusNames {
+		if statusName == name {
+			return status
+		}
+	}
+	return posts.PostStatus_POST_STATUS_PUBLISHED
+}
+
+// createdPostStatus validates the status and publish_at of a new post.
+// publish_at is only accepted for scheduled posts and must be in the future.
+func createdPostStatus(req *posts.CreatePostRequest) (posts.PostStatus, *time.Time, error) {
+	if _, ok := postStatusNames[req.Status]; !ok {
+		return 0, nil, invalidArgument("status", fmt.Sprintf("unknown status %d", req.Status))
+	}
+	if req.Status != posts.PostStatus_POST_STATUS_SCHEDULED {
+		if req.PublishAt != nil {
+			return 0, nil, invalidArgument("publish_at", "only scheduled posts have a publish time")
+		}
+		return req.Status, nil, nil
+	}
+	if req.PublishAt == nil {
+		return 0, nil, invalidArgument("publish_at", "scheduled posts need a publish time")
+	}
+	publishAt := req.PublishAt.AsTime()
+	if !publishAt.After(time.Now()) {
+		return 0, nil, invalidArgument("publish_at", "must be in the future")
+	}
+	return req.Status, &publishAt, nil
+}
+
+// addPublishedEvent records that the post became visible to others. The
+// event is always attributed to the creator.
+func addPublishedEvent(ctx context.Context, tx pgx.Tx, postId string, creatorId string) error {
+	return addEvent(ctx, tx, postId, creatorId, &events.PostEvent{
+		Payload: &events.PostEvent_PostPublished{PostPublished: &events.PostPublished{}},
+	})
+}
+
+// PublishPost publishes a draft or scheduled post of the caller right away,
+// or schedules it if publish_at is in the future. Published posts are
+// returned unchanged.
+func (s *PostServiceServer) PublishPost(ctx context.Context, req *posts.PublishPostRequest) (*posts.PublishPostResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT creator_id, status FROM posts WHERE post_id = $1 AND deleted_at IS NULL FOR UPDATE`
+	var creatorId, status string
+	err = tx.QueryRow(ctx, query, req.PostId).Scan(&creatorId, &status)
+	if err == pgx.ErrNoRows {
+		return nil, notFound("post", req.PostId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch post: %v\n", err)
+		return nil, dbError("failed to fetch post", err)
+	}
+	if actorUserId != creatorId {
+		// Drafts of other users are not visible at all.
+		if status != "published" {
+			return nil, notFound("post", req.PostId)
+		}
+		fmt.Printf("Unauthorized: actor does not match creator\n")
+		return nil, permissionDenied("NOT_POST_CREATOR", "actor does not match creator")
+	}
+	if status == "published" && req.PublishAt != nil {
+		return nil, aborted("POST_PUBLISHED", "post is already published")
+	}
+
+	var post *posts.Post
+	switch {
+	case status == "published":
+		query = `SELECT ` + postColumns + ` FROM posts WHERE post_id = $1`
+		post, err = scanPost(tx.QueryRow(ctx, query, req.PostId))
+	case req.PublishAt != nil && req.PublishAt.AsTime().After(time.Now()):
+		query = `UPDATE posts SET status = 'scheduled', publish_at = $2 WHERE post_id = $1 RETURNING ` + postColumns
+		post, err = scanPost(tx.QueryRow(ctx, query, req.PostId, req.PublishAt.AsTime()))
+	default:
+		// Published posts are listed by publish_at, not by when the draft
+		// was started.
+		query = `UPDATE posts SET status = 'published', publish_at = CURRENT_TIMESTAMP
+				 WHERE post_id = $1
+				 RETURNING ` + postColumns
+		post, err = scanPost(tx.QueryRow(ctx, query, req.PostId))
+		if err == nil {
+			err = addPublishedEvent(ctx, tx, req.PostId, creatorId)
+		}
+	}
+	if err != nil {
+		fmt.Printf("Failed to publish post: %v\n", err)
+		return nil, dbError("failed to publish post", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	if err := s.fillPostDetails(ctx, []*posts.Post{post}, actorUserId); err != nil {
+		return nil, err
+	}
+
+	return &posts.PublishPostResponse{Post: post}, nil
+}
+
+// ListMyDrafts lists the drafts and scheduled posts of the caller, most
+// recently created first.
+func (s *PostServiceServer) ListMyDrafts(ctx context.Context, req *posts.ListMyDraftsRequest) (*posts.ListMyDraftsResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDraftsLimit
+	}
+	if limit > maxDraftsLimit {
+		limit = maxDraftsLimit
+	}
+
+	var beforeCreatedAt *time.Time
+	var beforePostId string
+	if req.Cursor != "" {
+		createdAt, postId, err := decodeCursor(req.Cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			return nil, invalidArgument("cursor", err.Error())
+		}
+		beforeCreatedAt, beforePostId = &createdAt, postId
+	}
+
+	// Fetch one extra row to find out whether there is a next page.
+	query := `SELECT ` + postColumns + `
+			  FROM posts
+			  WHERE creator_id = $1 AND status IN ('draft', 'scheduled') AND deleted_at IS NULL
+			    AND ($2::timestamp IS NULL OR (created_at, post_id) < ($2, $3))
+			  ORDER BY created_at DESC, post_id DESC
+			  LIMIT $4`
+	rows, err := s.App.DB.Query(ctx, query, actorUserId, beforeCreatedAt, beforePostId, limit+1)
+	if err != nil {
+		fmt.Printf("Failed to fetch drafts: %v\n", err)
+		return nil, dbError("failed to fetch drafts", err)
+	}
+	defer rows.Close()
+
+	drafts := []*posts.Post{}
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			fmt.Printf("Failed to scan post: %v\n", err)
+			return nil, dbError("failed to scan post", err)
+		}
+		drafts = append(drafts, post)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	var nextCursor string
+	if len(drafts) > int(limit) {
+		drafts = drafts[:limit]
+		last := drafts[len(drafts)-1]
+		nextCursor = encodeCursor(last.CreatedAt.AsTime(), last.PostId)
+	}
+
+	if err := s.fillPostDetails(ctx, drafts, actorUserId); err != nil {
+		return nil, err
+	}
+
+	return &posts.ListMyDraftsResponse{Posts: drafts, NextCursor: nextCursor}, nil
+}
+
+// PostScheduler publishes scheduled posts once their publish_at comes and
+// emits a PostPublished event for each. Every post is published in its own
+// transaction under the row lock, so with several instances running each post
+// is still published exactly once.
+type PostScheduler struct {
+	DB       *pgxpool.Pool
+	Interval time.Duration
+}
+
+func (p *PostScheduler) Run(ctx context.Context) {
+	for {
+		published, err := p.publishBatch(ctx)
+		if err != nil {
+			fmt.Printf("Failed to publish scheduled posts: %v\n", err)
+		}
+		// Keep going while there is a backlog.
+		if err == nil && published == publishBatchSize {
+			continue
+		}
+		select {
+		case <-time.After(p.Interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// publishBatch publishes up to publishBatchSize due posts and returns how
+// many it found.
+func (p *PostScheduler) publishBatch(ctx context.Context) (int, error) {
+	query := `SELECT post_id FROM posts
+			  WHERE status = 'scheduled' AND publish_at <= CURRENT_TIMESTAMP AND deleted_at IS NULL
+			  ORDER BY publish_at
+			  LIMIT $1`
+	rows, err := p.DB.Query(ctx, query, publishBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	postIds, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, err
+	}
+
+	for _, postId := range postIds {
+		if err := p.publishPost(ctx, postId); err != nil {
+			return 0, err
+		}
+	}
+	return len(postIds), nil
+}
+
+// publishPost publishes the post if it is still due; it may have been
+// rescheduled, deleted or published by another instance since it was
+// selected.
+func (p *PostScheduler) publishPost(ctx context.Context, postId string) error {
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, postId); err != nil {
+		return err
+	}
+
+	// publish_at stays the requested time even if the scheduler is late, so
+	// the post is listed where its author placed it.
+	query := `UPDATE posts SET status = 'published'
+			  WHERE post_id = $1 AND status = 'scheduled' AND publish_at <= CURRENT_TIMESTAMP AND deleted_at IS NULL
+			  RETURNING creator_id`
+	var creatorId string
+	err = tx.QueryRow(ctx, query, postId).Scan(&creatorId)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := addPublishedEvent(ctx, tx, postId, creatorId); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain identifies this service in ErrorInfo details.
+const errorDomain = "posts.msg.i3cheese.ru"
+
+// invalidArgument reports a request field the service can not accept.
+func invalidArgument(field string, description string) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("invalid %s: %s", field, description))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// notFound reports a missing resource, such as a "post" with the given id.
+func notFound(resourceType string, name string) error {
+	st := status.New(codes.NotFound, fmt.Sprintf("%s %s not found", resourceType, name))
+	detailed, err := st.WithDetails(&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// permissionDenied reports that the actor may not perform the operation.
+// reason is a stable UPPER_SNAKE_CASE identifier clients can match on.
+func permissionDenied(reason string, message string) error {
+	st := status.New(codes.PermissionDenied, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// failedPrecondition reports that the operation was rejected because of the
+// current state of the resource. reason is like in permissionDenied.
+func failedPrecondition(reason string, message string) error {
+	st := status.New(codes.FailedPrecondition, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// aborted reports a conflict with a concurrent change that the caller can
+// resolve by reading the resource again. reason is like in permissionDenied.
+func aborted(reason string, message string) error {
+	st := status.New(codes.Aborted, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// missingMetadata is returned when a request arrives without the actor the
+// gateway is expected to attach.
+func missingMetadata() error {
+	return status.Error(codes.Unauthenticated, "failed to get metadata from context")
+}
+
+// actorFromContext returns the user the gateway makes the request on behalf
+// of, passed as actor_user_id metadata.
+func actorFromContext(ctx context.Context) (string, error) {
+	values := metadata.ValueFromIncomingContext(ctx, "actor_user_id")
+	if len(values) == 0 || values[0] == "" {
+		fmt.Printf("Failed to get actor from metadata\n")
+		return "", missingMetadata()
+	}
+	return values[0], nil
+}
+
+// dbError wraps a database failure. Failures to reach the database are
+// Unavailable, so callers know a retry may succeed; anything else is Internal.
+func dbError(message string, err error) error {
+	code := codes.Internal
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	if errors.As(err, &connectErr) || errors.As(err, &netErr) || pgconn.Timeout(err) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.Is(err, net.ErrClosed) {
+		code = codes.Unavailable
+	}
+	return status.Errorf(code, "%s: %v", message, err)
+}
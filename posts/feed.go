@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 100
+)
+
+// feedPosition is the (postListedAt, post_id) key the feed is ordered by.
+type feedPosition struct {
+	ListedAt time.Time
+	PostId   string
+}
+
+// FeedSource yields the home feed of a user: posts of the users they follow
+// and their own, newest first, strictly before the given position (or from
+// the newest post if it is nil), and only posts the user may see.
+//
+// PullFeedSource builds the feed at read time (fan-out on read). A source
+// maintaining precomputed per-user timelines (fan-out on write) can replace
+// it without changes to GetFeed.
+type FeedSource interface {
+	FeedPage(ctx context.Context, userId string, before *feedPosition, limit int) ([]*posts.Post, error)
+}
+
+type PullFeedSource struct {
+	App     *App
+	Follows FollowGraph
+}
+
+func (f *PullFeedSource) FeedPage(ctx context.Context, userId string, before *feedPosition, limit int) ([]*posts.Post, error) {
+	following, err := f.Follows.Following(ctx, userId)
+	if err != nil {
+		fmt.Printf("Failed to fetch followed users: %v\n", err)
+		// The follow graph lives in passport, so this is a dependency outage.
+		return nil, status.Errorf(codes.Unavailable, "failed to fetch followed users: %v", err)
+	}
+	authors := append(following, userId)
+
+	var beforeListedAt *time.Time
+	var beforePostId string
+	if before != nil {
+		beforeListedAt, beforePostId = &before.ListedAt, before.PostId
+	}
+
+	query := `SELECT ` + postColumns + `
+			  FROM posts
+			  WHERE creator_id = ANY($1)
+			    AND ` + listedPostCondition("posts", "$2") + `
+			    AND ($3::timestamp IS NULL OR (` + postListedAt + `, post_id) < ($3, $4))
+			  ORDER BY ` + postListedAt + ` DESC, post_id DESC
+			  LIMIT $5`
+	rows, err := f.App.DB.Query(ctx, query, authors, userId, beforeListedAt, beforePostId, limit)
+	if err != nil {
+		fmt.Printf("Failed to fetch feed: %v\n", err)
+		return nil, dbError("failed to fetch feed", err)
+	}
+	defer rows.Close()
+
+	postsList := []*posts.Post{}
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			fmt.Printf("Failed to scan post: %v\n", err)
+			return nil, dbError("failed to scan post", err)
+		}
+		postsList = append(postsList, post)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+	return postsList, nil
+}
+
+func (s *PostServiceServer) GetFeed(ctx context.Context, req *posts.GetFeedRequest) (*posts.GetFeedResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+
+	var before *feedPosition
+	if req.Cursor != "" {
+		at, postId, err := decodeCursor(req.Cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			return nil, invalidArgument("cursor", err.Error())
+		}
+		before = &feedPosition{ListedAt: at, PostId: postId}
+	}
+
+	// Fetch one extra post to find out whether there is a next page.
+	postsList, err := s.App.Feed.FeedPage(ctx, actorUserId, before, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor string
+	if len(postsList) > limit {
+		postsList = postsList[:limit]
+		last := postsList[len(postsList)-1]
+		nextCursor = encodeCursor(listedAt(last), last.PostId)
+	}
+
+	if err := s.fillPostDetails(ctx, postsList, actorUserId); err != nil {
+		return nil, err
+	}
+
+	return &posts.GetFeedResponse{Posts: postsList, NextCursor: nextCursor}, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// FollowGraph tells whom a user follows. Subscriptions are owned by
+// passport, so the posts service asks it instead of keeping its own copy.
+type FollowGraph interface {
+	Following(ctx context.Context, userId string) ([]string, error)
+}
+
+// PassportFollowGraph reads subscriptions from the passport HTTP API.
+type PassportFollowGraph struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+func NewPassportFollowGraph(baseURL string) *PassportFollowGraph {
+	return &PassportFollowGraph{
+		BaseURL: baseURL,
+		Client:  &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+func (g *PassportFollowGraph) Following(ctx context.Context, userId string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.BaseURL+"/users/"+url.PathEscape(userId)+"/following_ids", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("passport responded with %v", resp.Status)
+	}
+
+	var body struct {
+		UserIds []string `json:"user_ids"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body.UserIds, nil
+}
@@ -5,41 +5,153 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"msg.i3cheese.ru/proto/posts"
+	"msg.i3cheese.ru/proto/statistics"
 )
 
 type App struct {
-	DB *pgx.Conn
+	DB   *pgxpool.Pool
+	Feed FeedSource
+	// Statistics is nil when STATISTICS_URL is not set; GetTrending is then
+	// unavailable.
+	Statistics statistics.StatisticsServiceClient
+	// SearchConfig is the Postgres text search configuration posts are
+	// indexed and searched with.
+	SearchConfig string
+	// TrashRetention is how long deleted posts can be restored before they
+	// are purged.
+	TrashRetention time.Duration
 }
 
-func connectWithRetries(ctx context.Context, dsn string, maxRetries int) (*pgx.Conn, error) {
-	var conn *pgx.Conn
+func connectWithRetries(ctx context.Context, dsn string, maxRetries int) (*pgxpool.Pool, error) {
+	var conn *pgxpool.Pool
 	var err error
 	for i := 0; i < maxRetries; i++ {
-		conn, err = pgx.Connect(ctx, dsn)
+		conn, err = pgxpool.New(ctx, dsn)
+		if err == nil {
+			err = conn.Ping(ctx)
+		}
 		if err == nil {
 			return conn, nil
 		}
+		if conn != nil {
+			conn.Close()
+		}
 		fmt.Fprintf(os.Stderr, "Attempt %d: Unable to connect to database: %v\n", i+1, err)
 		time.Sleep(2 * time.Second) // Add a delay between retries
 	}
 	return nil, err
 }
 
+// durationFromEnv parses the duration in the given variable, falling back to
+// the default when it is not set.
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		fmt.Printf("%s must be a positive duration such as 720h, got %q\n", name, value)
+		os.Exit(1)
+	}
+	return duration
+}
+
+func newPublisher(statisticsClient statistics.StatisticsServiceClient) Publisher {
+	switch os.Getenv("BROKER") {
+	case "", "direct":
+		if statisticsClient == nil {
+			fmt.Println("STATISTICS_URL is required unless BROKER=kafka")
+			os.Exit(1)
+		}
+		return &StatisticsPublisher{Client: statisticsClient}
+	case "kafka":
+		brokers := os.Getenv("KAFKA_BROKERS")
+		if brokers == "" {
+			fmt.Println("KAFKA_BROKERS is required")
+			os.Exit(1)
+		}
+		topic := os.Getenv("POST_EVENTS_TOPIC")
+		if topic == "" {
+			topic = "post-events"
+		}
+		return NewKafkaPublisher(strings.Split(brokers, ","), topic)
+	default:
+		fmt.Printf("Unknown BROKER %q, expected direct or kafka\n", os.Getenv("BROKER"))
+		os.Exit(1)
+		return nil
+	}
+}
+
 func main() {
 	conn, err := connectWithRetries(context.Background(), os.Getenv("DATABASE_URL"), 10)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Unable to connect to database after retries: %v\n", err)
 		os.Exit(1)
 	}
-	defer conn.Close(context.Background())
+	defer conn.Close()
 
-	app := &App{DB: conn}
+	passportURL := os.Getenv("PASSPORT_URL")
+	if passportURL == "" {
+		fmt.Println("PASSPORT_URL is required")
+		os.Exit(1)
+	}
+
+	searchConfig := os.Getenv("SEARCH_LANGUAGE")
+	if searchConfig == "" {
+		searchConfig = defaultSearchConfig
+	}
+	if err := setupSearch(context.Background(), conn, searchConfig); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to set up search: %v\n", err)
+		os.Exit(1)
+	}
+
+	app := &App{
+		DB:             conn,
+		SearchConfig:   searchConfig,
+		TrashRetention: durationFromEnv("TRASH_RETENTION", defaultTrashRetention),
+	}
+	app.Feed = &PullFeedSource{App: app, Follows: NewPassportFollowGraph(passportURL)}
+
+	if statisticsURL := os.Getenv("STATISTICS_URL"); statisticsURL != "" {
+		statisticsConn, err := grpc.NewClient(statisticsURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			fmt.Printf("Failed to create statistics client: %v\n", err)
+			os.Exit(1)
+		}
+		defer statisticsConn.Close()
+		app.Statistics = statistics.NewStatisticsServiceClient(statisticsConn)
+	}
+
+	publisher := newPublisher(app.Statistics)
+	defer publisher.Close()
+	relay := &OutboxRelay{DB: conn, Publisher: publisher}
+	go relay.Run(context.Background())
+	purger := &PostPurger{
+		DB:        conn,
+		Retention: app.TrashRetention,
+		Interval:  durationFromEnv("TRASH_PURGE_INTERVAL", defaultPurgeInterval),
+	}
+	go purger.Run(context.Background())
+	scheduler := &PostScheduler{
+		DB:       conn,
+		Interval: durationFromEnv("SCHEDULER_INTERVAL", defaultSchedulerInterval),
+	}
+	go scheduler.Run(context.Background())
+	collector := &AttachmentCollector{
+		DB:       conn,
+		TTL:      durationFromEnv("ATTACHMENT_TTL", defaultAttachmentTTL),
+		Interval: durationFromEnv("ATTACHMENT_COLLECT_INTERVAL", defaultAttachmentCollectInterval),
+	}
+	go collector.Run(context.Background())
 
 	grpcServer := grpc.NewServer()
 	postService := &PostServiceServer{App: app}
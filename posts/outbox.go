@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/events"
+)
+
+const (
+	outboxBatchSize     = 100
+	outboxRelayInterval = 500 * time.Millisecond
+)
+
+// lockPostEvents serializes transactions that emit events for the same post
+// until they commit. Outbox ids are assigned under the lock, so for every
+// post they follow commit order, which the relay relies on. Transactions
+// changing an existing post take the lock before their first change, so they
+// never wait for it while holding row locks another of them needs.
+func lockPostEvents(ctx context.Context, tx pgx.Tx, postId string) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, postId)
+	if err != nil {
+		fmt.Printf("Failed to lock post events: %v\n", err)
+		return dbError("failed to lock post events", err)
+	}
+	return nil
+}
+
+// addEvent stores event in the outbox as part of tx, so it is published if
+// and only if tx commits. The envelope fields are filled in here. The lock is
+// taken again, which is a no-op if tx already holds it, for new posts whose
+// id was not known before.
+func addEvent(ctx context.Context, tx pgx.Tx, postId string, actorUserId string, event *events.PostEvent) error {
+	if err := lockPostEvents(ctx, tx, postId); err != nil {
+		return err
+	}
+
+	event.EventId = newEventId()
+	event.OccurredAt = timestamppb.Now()
+	event.PostId = postId
+	event.ActorUserId = actorUserId
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to encode event: %v", err)
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO outbox (post_id, payload) VALUES ($1, $2)`, postId, payload)
+	if err != nil {
+		fmt.Printf("Failed to add event to outbox: %v\n", err)
+		return dbError("failed to add event to outbox", err)
+	}
+	return nil
+}
+
+// newEventId returns a random UUID.
+func newEventId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// OutboxRelay moves events from the outbox to the publisher. An event is
+// removed from the outbox only after it is published, so it is delivered at
+// least once. Relays of several instances lock the oldest rows and so take
+// turns rather than publishing out of order.
+type OutboxRelay struct {
+	DB        *pgxpool.Pool
+	Publisher Publisher
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	for {
+		published, err := r.relayBatch(ctx)
+		if err != nil {
+			fmt.Printf("Failed to relay outbox: %v\n", err)
+		}
+		// Keep draining while there is a backlog.
+		if err == nil && published == outboxBatchSize {
+			continue
+		}
+		select {
+		case <-time.After(outboxRelayInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id, post_id, payload FROM outbox ORDER BY id LIMIT $1 FOR UPDATE`, outboxBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch outbox: %w", err)
+	}
+	var ids []int64
+	var messages []Message
+	for rows.Next() {
+		var id int64
+		var message Message
+		if err := rows.Scan(&id, &message.Key, &message.Value); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox: %w", err)
+		}
+		ids = append(ids, id)
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to fetch outbox: %w", err)
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	if err := r.Publisher.Publish(ctx, messages); err != nil {
+		return 0, fmt.Errorf("failed to publish events: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM outbox WHERE id = ANY($1)`, ids); err != nil {
+		return 0, fmt.Errorf("failed to delete published events: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(messages), nil
+}
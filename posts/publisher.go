@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+
+	"msg.i3cheese.ru/proto/statistics"
+)
+
+// Message is an event ready to be published. Messages with the same Key are
+// delivered to consumers in the order they were published.
+type Message struct {
+	Key   string
+	Value []byte
+}
+
+// Publisher sends events to a broker. Publish returns once every message is
+// accepted by the broker, or with an error, in which case any of them may
+// still have been delivered.
+type Publisher interface {
+	Publish(ctx context.Context, messages []Message) error
+	Close() error
+}
+
+// KafkaPublisher publishes to a Kafka topic, partitioning by message key.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string, topic string) *KafkaPublisher {
+	return &KafkaPublisher{writer: &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, messages []Message) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		kafkaMessages = append(kafkaMessages, kafka.Message{Key: []byte(message.Key), Value: message.Value})
+	}
+	return p.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+// StatisticsPublisher hands events straight to the statistics service, for
+// deployments without Kafka. Statistics records them before answering, so
+// the outbox keeps at-least-once delivery.
+type StatisticsPublisher struct {
+	Client statistics.StatisticsServiceClient
+}
+
+func (p *StatisticsPublisher) Publish(ctx context.Context, messages []Message) error {
+	req := &statistics.PublishEventsRequest{PostEvents: make([][]byte, 0, len(messages))}
+	for _, message := range messages {
+		req.PostEvents = append(req.PostEvents, message.Value)
+	}
+	_, err := p.Client.PublishEvents(ctx, req)
+	return err
+}
+
+func (p *StatisticsPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/events"
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	defaultReactionsLimit = 50
+	maxReactionsLimit     = 200
+)
+
+// reactionTypes must be kept in sync with the post_likes CHECK constraint.
+var reactionTypes = []string{"like", "love", "laugh", "wow", "sad", "angry"}
+
+func isValidReactionType(reactionType string) bool {
+	return slices.Contains(reactionTypes, reactionType)
+}
+
+// fillReactions sets reaction counts and the actor's own reaction on every
+// post in postsList using one query for each.
+func (s *PostServiceServer) fillReactions(ctx context.Context, postsList []*posts.Post, actorUserId string) error {
+	if len(postsList) == 0 {
+		return nil
+	}
+	byId := make(map[string]*posts.Post, len(postsList))
+	postIds := make([]string, 0, len(postsList))
+	for _, post := range postsList {
+		post.ReactionCounts = map[string]int32{}
+		post.MyReaction = ""
+		byId[post.PostId] = post
+		postIds = append(postIds, post.PostId)
+	}
+
+	query := `SELECT post_id, reaction_type, COUNT(*) FROM post_likes WHERE post_id = ANY($1) GROUP BY post_id, reaction_type`
+	rows, err := s.App.DB.Query(ctx, query, postIds)
+	if err != nil {
+		fmt.Printf("Failed to fetch reaction counts: %v\n", err)
+		return dbError("failed to fetch reaction counts", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var postId, reactionType string
+		var count int32
+		if err := rows.Scan(&postId, &reactionType, &count); err != nil {
+			fmt.Printf("Failed to scan reaction count: %v\n", err)
+			return dbError("failed to scan reaction count", err)
+		}
+		byId[postId].ReactionCounts[reactionType] = count
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return dbError("error iterating over rows", err)
+	}
+
+	query = `SELECT post_id, reaction_type FROM post_likes WHERE post_id = ANY($1) AND user_id = $2`
+	rows, err = s.App.DB.Query(ctx, query, postIds, actorUserId)
+	if err != nil {
+		fmt.Printf("Failed to fetch own reactions: %v\n", err)
+		return dbError("failed to fetch own reactions", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var postId, reactionType string
+		if err := rows.Scan(&postId, &reactionType); err != nil {
+			fmt.Printf("Failed to scan own reaction: %v\n", err)
+			return dbError("failed to scan own reaction", err)
+		}
+		byId[postId].MyReaction = reactionType
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return dbError("error iterating over rows", err)
+	}
+	return nil
+}
+
+func (s *PostServiceServer) SetReaction(ctx context.Context, req *posts.SetReactionRequest) (*posts.SetReactionResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isValidReactionType(req.ReactionType) {
+		return nil, invalidArgument("reaction_type", fmt.Sprintf("unknown reaction type %q", req.ReactionType))
+	}
+	if err := s.checkPostAccess(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	var previousReactionType string
+	query := `SELECT reaction_type FROM post_likes WHERE post_id = $1 AND user_id = $2`
+	err = tx.QueryRow(ctx, query, req.PostId, actorUserId).Scan(&previousReactionType)
+	if err != nil && err != pgx.ErrNoRows {
+		fmt.Printf("Failed to fetch reaction: %v\n", err)
+		return nil, dbError("failed to fetch reaction", err)
+	}
+
+	// A user has at most one reaction per post, so reacting again replaces it.
+	query = `INSERT INTO post_likes (post_id, user_id, reaction_type) VALUES ($1, $2, $3)
+			  ON CONFLICT (post_id, user_id) DO UPDATE SET reaction_type = EXCLUDED.reaction_type, created_at = CURRENT_TIMESTAMP
+			  RETURNING created_at`
+	var createdAt time.Time
+	err = tx.QueryRow(ctx, query, req.PostId, actorUserId, req.ReactionType).Scan(&createdAt)
+	if err != nil {
+		fmt.Printf("Failed to set reaction: %v\n", err)
+		return nil, dbError("failed to set reaction", err)
+	}
+	err = addEvent(ctx, tx, req.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_ReactionSet{ReactionSet: &events.ReactionSet{
+			ReactionType:         req.ReactionType,
+			PreviousReactionType: previousReactionType,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	return &posts.SetReactionResponse{Reaction: &posts.Reaction{
+		PostId:       req.PostId,
+		UserId:       actorUserId,
+		ReactionType: req.ReactionType,
+		CreatedAt:    timestamppb.New(createdAt),
+	}}, nil
+}
+
+func (s *PostServiceServer) RemoveReaction(ctx context.Context, req *posts.RemoveReactionRequest) (*posts.RemoveReactionResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	query := `DELETE FROM post_likes WHERE post_id = $1 AND user_id = $2 RETURNING reaction_type`
+	var reactionType string
+	err = tx.QueryRow(ctx, query, req.PostId, actorUserId).Scan(&reactionType)
+	if err == pgx.ErrNoRows {
+		return &posts.RemoveReactionResponse{Success: false}, nil
+	}
+	if err != nil {
+		fmt.Printf("Failed to remove reaction: %v\n", err)
+		return nil, dbError("failed to remove reaction", err)
+	}
+	err = addEvent(ctx, tx, req.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_ReactionRemoved{ReactionRemoved: &events.ReactionRemoved{ReactionType: reactionType}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	return &posts.RemoveReactionResponse{Success: true}, nil
+}
+
+func (s *PostServiceServer) ListReactions(ctx context.Context, req *posts.ListReactionsRequest) (*posts.ListReactionsResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ReactionType != "" && !isValidReactionType(req.ReactionType) {
+		return nil, invalidArgument("reaction_type", fmt.Sprintf("unknown reaction type %q", req.ReactionType))
+	}
+	if err := s.checkPostAccess(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultReactionsLimit
+	}
+	if limit > maxReactionsLimit {
+		limit = maxReactionsLimit
+	}
+
+	afterCreatedAt := time.Unix(0, 0).UTC()
+	afterUserId := ""
+	if req.Cursor != "" {
+		var err error
+		afterCreatedAt, afterUserId, err = decodeCursor(req.Cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			return nil, invalidArgument("cursor", err.Error())
+		}
+	}
+
+	// Fetch one extra row to find out whether there is a next page.
+	query := `SELECT user_id, reaction_type, created_at
+			  FROM post_likes
+			  WHERE post_id = $1
+			    AND ($2 = '' OR reaction_type = $2)
+			    AND (created_at, user_id) > ($3, $4)
+			  ORDER BY created_at ASC, user_id ASC
+			  LIMIT $5`
+	rows, err := s.App.DB.Query(ctx, query, req.PostId, req.ReactionType, afterCreatedAt, afterUserId, limit+1)
+	if err != nil {
+		fmt.Printf("Failed to fetch reactions: %v\n", err)
+		return nil, dbError("failed to fetch reactions", err)
+	}
+	defer rows.Close()
+
+	reactions := []*posts.Reaction{}
+	for rows.Next() {
+		reaction := posts.Reaction{PostId: req.PostId}
+		var createdAt time.Time
+		if err := rows.Scan(&reaction.UserId, &reaction.ReactionType, &createdAt); err != nil {
+			fmt.Printf("Failed to scan reaction: %v\n", err)
+			return nil, dbError("failed to scan reaction", err)
+		}
+		reaction.CreatedAt = timestamppb.New(createdAt)
+		reactions = append(reactions, &reaction)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	var nextCursor string
+	if len(reactions) > int(limit) {
+		reactions = reactions[:limit]
+		last := reactions[len(reactions)-1]
+		nextCursor = encodeCursor(last.CreatedAt.AsTime(), last.UserId)
+	}
+
+	// Reuse the aggregation that decorates posts.
+	post := &posts.Post{PostId: req.PostId}
+	if err := s.fillReactions(ctx, []*posts.Post{post}, actorUserId); err != nil {
+		return nil, err
+	}
+
+	return &posts.ListReactionsResponse{
+		Reactions:      reactions,
+		NextCursor:     nextCursor,
+		ReactionCounts: post.ReactionCounts,
+	}, nil
+}
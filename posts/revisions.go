@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	defaultRevisionsLimit = 20
+	maxRevisionsLimit     = 100
+)
+
+const revisionColumns = `post_id, revision, title, description, is_private, editor_id, created_at`
+
+func scanRevision(row pgx.Row) (*posts.PostRevision, error) {
+	var revision posts.PostRevision
+	var createdAt time.Time
+	err := row.Scan(&revision.PostId, &revision.Revision, &revision.Title, &revision.Description, &revision.IsPrivate, &revision.EditorId, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	revision.CreatedAt = timestamppb.New(createdAt)
+	return &revision, nil
+}
+
+// addRevision records the content post has after editorId created or updated
+// it. post.Revision must already be the new revision number.
+func addRevision(ctx context.Context, tx pgx.Tx, post *posts.Post, editorId string) error {
+	query := `INSERT INTO post_revisions (` + revisionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, query, post.PostId, post.Revision, post.Title, post.Description, post.IsPrivate, editorId, post.UpdatedAt.AsTime())
+	if err != nil {
+		fmt.Printf("Failed to add revision: %v\n", err)
+		return dbError("failed to add revision", err)
+	}
+	return nil
+}
+
+// checkPostCreator fails unless the actor created the post. Posts the actor
+// can not see are reported as NotFound, like in checkPostAccess.
+func (s *PostServiceServer) checkPostCreator(ctx context.Context, postId string, actorUserId string) error {
+	query := `SELECT p.creator_id, ` + visiblePostCondition("p", "$2") + ` FROM posts p WHERE p.post_id = $1`
+	var creatorId string
+	var visible bool
+	err := s.App.DB.QueryRow(ctx, query, postId, actorUserId).Scan(&creatorId, &visible)
+	if err == pgx.ErrNoRows || (err == nil && !visible) {
+		return notFound("post", postId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch post: %v\n", err)
+		return dbError("failed to fetch post", err)
+	}
+	if actorUserId != creatorId {
+		fmt.Printf("Unauthorized: actor does not match creator\n")
+		return permissionDenied("NOT_POST_CREATOR", "actor does not match creator")
+	}
+	return nil
+}
+
+func (s *PostServiceServer) ListPostRevisions(ctx context.Context, req *posts.ListPostRevisionsRequest) (*posts.ListPostRevisionsResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPostCreator(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultRevisionsLimit
+	}
+	if limit > maxRevisionsLimit {
+		limit = maxRevisionsLimit
+	}
+
+	// Revision numbers are positive, so zero means "from the latest".
+	var beforeRevision int32
+	if req.Cursor != "" {
+		var err error
+		beforeRevision, err = decodeRevisionCursor(req.Cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			return nil, invalidArgument("cursor", err.Error())
+		}
+	}
+
+	// Fetch one extra row to find out whether there is a next page.
+	query := `SELECT ` + revisionColumns + `
+			  FROM post_revisions
+			  WHERE post_id = $1 AND ($2 = 0 OR revision < $2)
+			  ORDER BY revision DESC
+			  LIMIT $3`
+	rows, err := s.App.DB.Query(ctx, query, req.PostId, beforeRevision, limit+1)
+	if err != nil {
+		fmt.Printf("Failed to fetch revisions: %v\n", err)
+		return nil, dbError("failed to fetch revisions", err)
+	}
+	defer rows.Close()
+
+	revisions := []*posts.PostRevision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			fmt.Printf("Failed to scan revision: %v\n", err)
+			return nil, dbError("failed to scan revision", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	var nextCursor string
+	if len(revisions) > int(limit) {
+		revisions = revisions[:limit]
+		nextCursor = encodeRevisionCursor(revisions[len(revisions)-1].Revision)
+	}
+
+	return &posts.ListPostRevisionsResponse{Revisions: revisions, NextCursor: nextCursor}, nil
+}
+
+func (s *PostServiceServer) GetPostRevision(ctx context.Context, req *posts.GetPostRevisionRequest) (*posts.GetPostRevisionResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkPostCreator(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + revisionColumns + ` FROM post_revisions WHERE post_id = $1 AND revision = $2`
+	revision, err := scanRevision(s.App.DB.QueryRow(ctx, query, req.PostId, req.Revision))
+	if err == pgx.ErrNoRows {
+		return nil, notFound("revision", fmt.Sprintf("%s/%d", req.PostId, req.Revision))
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch revision: %v\n", err)
+		return nil, dbError("failed to fetch revision", err)
+	}
+
+	return &posts.GetPostRevisionResponse{Revision: revision}, nil
+}
+
+// RestorePostRevision is an UpdatePost with the content of the revision, so
+// it is recorded as a new revision and published as a PostUpdated event.
+func (s *PostServiceServer) RestorePostRevision(ctx context.Context, req *posts.RestorePostRevisionRequest) (*posts.RestorePostRevisionResponse, error) {
+	resp, err := s.GetPostRevision(ctx, &posts.GetPostRevisionRequest{PostId: req.PostId, Revision: req.Revision})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.UpdatePost(ctx, &posts.UpdatePostRequest{
+		PostId:            req.PostId,
+		Title:             resp.Revision.Title,
+		Description:       resp.Revision.Description,
+		IsPrivate:         resp.Revision.IsPrivate,
+		UpdateMask:        &fieldmaskpb.FieldMask{Paths: []string{"title", "description", "is_private"}},
+		ExpectedUpdatedAt: req.ExpectedUpdatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &posts.RestorePostRevisionResponse{Post: updated.Post}, nil
+}
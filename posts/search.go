@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	maxSearchQueryLen  = 256
+	// defaultSearchConfig handles Russian and, since it stems ASCII words as
+	// English, English posts.
+	defaultSearchConfig = "russian"
+)
+
+// Matched words are wrapped in these private-use characters by Postgres and
+// replaced with <mark> tags after the rest of the text is escaped, so post
+// content can never inject markup.
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+var (
+	titleHighlightOptions = fmt.Sprintf("HighlightAll=true, StartSel=\"%s\", StopSel=\"%s\"", highlightStart, highlightStop)
+	snippetOptions        = fmt.Sprintf(`MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … ", StartSel="%s", StopSel="%s"`, highlightStart, highlightStop)
+)
+
+// setupSearch checks that config is a Postgres text search configuration
+// and reindexes posts indexed with a different one, which happens after
+// SEARCH_LANGUAGE changes.
+func setupSearch(ctx context.Context, db *pgxpool.Pool, config string) error {
+	if _, err := db.Exec(ctx, `SELECT $1::regconfig`, config); err != nil {
+		return fmt.Errorf("unknown text search configuration %q: %w", config, err)
+	}
+	tag, err := db.Exec(ctx, `UPDATE posts SET search_config = $1::regconfig WHERE search_config <> $1::regconfig`, config)
+	if err != nil {
+		return fmt.Errorf("failed to reindex posts: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		fmt.Printf("Reindexed %d posts with text search configuration %s\n", tag.RowsAffected(), config)
+	}
+	return nil
+}
+
+// renderHighlight escapes text produced by ts_headline and turns the
+// highlight markers into <mark> tags.
+func renderHighlight(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, highlightStart, "<mark>")
+	return strings.ReplaceAll(text, highlightStop, "</mark>")
+}
+
+func (s *PostServiceServer) SearchPosts(ctx context.Context, req *posts.SearchPostsRequest) (*posts.SearchPostsResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, invalidArgument("query", "query is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLen {
+		return nil, invalidArgument("query", fmt.Sprintf("query is longer than %d characters", maxSearchQueryLen))
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		fmt.Printf("Invalid tags: %v\n", err)
+		return nil, invalidArgument("tags", err.Error())
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	tsquery := fmt.Sprintf("websearch_to_tsquery(%s::regconfig, %s)", arg(s.App.SearchConfig), arg(query))
+	conditions := []string{
+		"search_vector @@ " + tsquery,
+		listedPostCondition("posts", arg(actorUserId)),
+	}
+	if req.CreatorId != "" {
+		conditions = append(conditions, "creator_id = "+arg(req.CreatorId))
+	}
+	if len(tags) > 0 {
+		conditions = append(conditions, tagFilterCondition(req.TagMatch, arg(tags)))
+	}
+	if req.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= "+arg(req.CreatedAfter.AsTime()))
+	}
+	if req.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < "+arg(req.CreatedBefore.AsTime()))
+	}
+	position := "TRUE"
+	if req.Cursor != "" {
+		rank, postId, err := decodeSearchCursor(req.Cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			return nil, invalidArgument("cursor", err.Error())
+		}
+		position = fmt.Sprintf("(rank, post_id) < (%s::real, %s)", arg(rank), arg(postId))
+	}
+
+	// Headlines are costly, so they are only built for the page. One extra
+	// row tells whether there is a next page.
+	sql := `SELECT ` + postColumns + `, rank,
+			       ts_headline(search_config, title, ` + tsquery + `, ` + arg(titleHighlightOptions) + `),
+			       ts_headline(search_config, description, ` + tsquery + `, ` + arg(snippetOptions) + `)
+			FROM (
+			    SELECT * FROM (
+			        SELECT posts.*, ts_rank_cd(search_vector, ` + tsquery + `) AS rank
+			        FROM posts
+			        WHERE ` + strings.Join(conditions, " AND ") + `
+			    ) matched
+			    WHERE ` + position + `
+			    ORDER BY rank DESC, post_id DESC
+			    LIMIT ` + arg(limit+1) + `
+			) page
+			ORDER BY rank DESC, post_id DESC`
+	rows, err := s.App.DB.Query(ctx, sql, args...)
+	if err != nil {
+		fmt.Printf("Failed to search posts: %v\n", err)
+		return nil, dbError("failed to search posts", err)
+	}
+	defer rows.Close()
+
+	results := []*posts.SearchResult{}
+	for rows.Next() {
+		var result posts.SearchResult
+		var titleHighlight, snippet string
+		post, err := scanPost(rowScanner(func(dest ...any) error {
+			return rows.Scan(append(dest, &result.Rank, &titleHighlight, &snippet)...)
+		}))
+		if err != nil {
+			fmt.Printf("Failed to scan post: %v\n", err)
+			return nil, dbError("failed to scan post", err)
+		}
+		result.Post = post
+		result.TitleHighlight = renderHighlight(titleHighlight)
+		result.DescriptionSnippet = renderHighlight(snippet)
+		results = append(results, &result)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	resp := &posts.SearchPostsResponse{}
+	if len(results) > limit {
+		results = results[:limit]
+		last := results[len(results)-1]
+		resp.NextCursor = encodeSearchCursor(last.Rank, last.Post.PostId)
+	}
+
+	postsList := make([]*posts.Post, 0, len(results))
+	for _, result := range results {
+		postsList = append(postsList, result.Post)
+	}
+	if err := s.fillPostDetails(ctx, postsList, actorUserId); err != nil {
+		return nil, err
+	}
+	resp.Results = results
+	return resp, nil
+}
+
+// rowScanner adapts a scan function to pgx.Row so scanPost can read the
+// leading columns of a wider row.
+type rowScanner func(dest ...any) error
+
+func (f rowScanner) Scan(dest ...any) error {
+	return f(dest...)
+}
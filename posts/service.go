@@ -3,11 +3,15 @@ package main
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
-	"google.golang.org/grpc/metadata"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"msg.i3cheese.ru/proto/events"
 	"msg.i3cheese.ru/proto/posts"
 )
 
@@ -16,198 +20,534 @@ type PostServiceServer struct {
 	App *App
 }
 
+const (
+	defaultPostsLimit = 10
+	maxPostsLimit     = 100
+)
+
+const postColumns = `post_id, title, description, creator_id, created_at, updated_at, is_private, revision, status, publish_at`
+
+// postListedAt is the time listings and the feed order posts by: when they
+// were published, or when they were created if they never were.
+const postListedAt = `COALESCE(publish_at, created_at)`
+
+// listedAt returns the postListedAt time of a scanned post, for cursors.
+func listedAt(post *posts.Post) time.Time {
+	if post.PublishAt != nil {
+		return post.PublishAt.AsTime()
+	}
+	return post.CreatedAt.AsTime()
+}
+
+func scanPost(row pgx.Row) (*posts.Post, error) {
+	var post posts.Post
+	var createdAt, updatedAt time.Time
+	var status string
+	var publishAt *time.Time
+	err := row.Scan(&post.PostId, &post.Title, &post.Description, &post.CreatorId, &createdAt, &updatedAt, &post.IsPrivate, &post.Revision, &status, &publishAt)
+	if err != nil {
+		return nil, err
+	}
+	post.CreatedAt = timestamppb.New(createdAt)
+	post.UpdatedAt = timestamppb.New(updatedAt)
+	post.Edited = post.Revision > 1
+	post.Status = postStatusFromName(status)
+	if publishAt != nil {
+		post.PublishAt = timestamppb.New(*publishAt)
+	}
+	return &post, nil
+}
+
 func (s *PostServiceServer) CreatePost(ctx context.Context, req *posts.CreatePostRequest) (*posts.CreatePostResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		fmt.Printf("Failed to get metadata from context\n")
-		return nil, fmt.Errorf("failed to get metadata from context")
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
-	actorUserId := md.Get("actor_user_id")[0]
 
-	// Implement logic to create a post in the database
-	query := `INSERT INTO posts (title, description, creator_id, is_private) VALUES ($1, $2, $3, $4) RETURNING post_id, created_at, updated_at`
-	row := s.App.DB.QueryRow(ctx, query, req.Title, req.Description, actorUserId, req.IsPrivate)
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		fmt.Printf("Invalid tags: %v\n", err)
+		return nil, invalidArgument("tags", err.Error())
+	}
+	status, publishAt, err := createdPostStatus(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAttachmentIds(req.AttachmentIds); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Implement logic to create a post in the database. Posts published
+	// right away get the creation time as publish_at.
+	query := `INSERT INTO posts (title, description, creator_id, is_private, search_config, status, publish_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, CASE WHEN $8 THEN CURRENT_TIMESTAMP ELSE $7 END)
+			  RETURNING post_id, created_at, updated_at, publish_at`
+	publishNow := status == posts.PostStatus_POST_STATUS_PUBLISHED
+	row := tx.QueryRow(ctx, query, req.Title, req.Description, actorUserId, req.IsPrivate, s.App.SearchConfig, postStatusNames[status], publishAt, publishNow)
 
 	var post posts.Post
 	post.Title = req.Title
 	post.Description = req.Description
 	post.CreatorId = actorUserId
 	post.IsPrivate = req.IsPrivate
+	post.Tags = tags
+	post.ReactionCounts = map[string]int32{}
+	post.Revision = 1
+	post.Status = status
 
 	var createdAt, updatedAt time.Time
-	err := row.Scan(&post.PostId, &createdAt, &updatedAt)
+	err = row.Scan(&post.PostId, &createdAt, &updatedAt, &publishAt)
 	if err == nil {
 		post.CreatedAt = timestamppb.New(createdAt)
 		post.UpdatedAt = timestamppb.New(updatedAt)
+		if publishAt != nil {
+			post.PublishAt = timestamppb.New(*publishAt)
+		}
 	}
 	if err != nil {
 		fmt.Printf("Failed to create post: %v\n", err)
-		return nil, fmt.Errorf("failed to create post: %v", err)
+		return nil, dbError("failed to create post", err)
+	}
+
+	if err := setPostTags(ctx, tx, post.PostId, tags); err != nil {
+		return nil, err
+	}
+	if err := setPostAttachments(ctx, tx, post.PostId, actorUserId, req.AttachmentIds); err != nil {
+		return nil, err
+	}
+	if err := addRevision(ctx, tx, &post, actorUserId); err != nil {
+		return nil, err
+	}
+	err = addEvent(ctx, tx, post.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_PostCreated{PostCreated: &events.PostCreated{
+			CreatorId: actorUserId,
+			Title:     post.Title,
+			IsPrivate: post.IsPrivate,
+			Tags:      tags,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if publishNow {
+		if err := addPublishedEvent(ctx, tx, post.PostId, actorUserId); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	if err := s.fillAttachments(ctx, []*posts.Post{&post}); err != nil {
+		return nil, err
 	}
 
 	return &posts.CreatePostResponse{Post: &post}, nil
 }
 
 func (s *PostServiceServer) DeletePost(ctx context.Context, req *posts.DeletePostRequest) (*posts.DeletePostResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		fmt.Printf("Failed to get metadata from context\n")
-		return nil, fmt.Errorf("failed to get metadata from context")
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
 	}
-	actorUserId := md.Get("actor_user_id")[0]
 
-	query := `SELECT creator_id FROM posts WHERE post_id = $1`
-	row := s.App.DB.QueryRow(ctx, query, req.PostId)
+	query := `SELECT creator_id FROM posts WHERE post_id = $1 AND deleted_at IS NULL FOR UPDATE`
+	row := tx.QueryRow(ctx, query, req.PostId)
 
 	var creatorId string
-	err := row.Scan(&creatorId)
+	err = row.Scan(&creatorId)
+	if err == pgx.ErrNoRows {
+		return nil, notFound("post", req.PostId)
+	}
 	if err != nil {
 		fmt.Printf("Failed to fetch post: %v\n", err)
-		return nil, fmt.Errorf("failed to fetch post: %v", err)
+		return nil, dbError("failed to fetch post", err)
 	}
 	if actorUserId != creatorId {
 		fmt.Printf("Unauthorized: actor does not match creator\n")
-		return nil, fmt.Errorf("unauthorized: actor does not match creator")
+		return nil, permissionDenied("NOT_POST_CREATOR", "actor does not match creator")
 	}
 
-	// Implement logic to delete a post from the database
-	query = `DELETE FROM posts WHERE post_id = $1`
-	_, err = s.App.DB.Exec(ctx, query, req.PostId)
+	// The post only moves to the trash; PostPurger removes it later.
+	query = `UPDATE posts SET deleted_at = CURRENT_TIMESTAMP WHERE post_id = $1 RETURNING deleted_at`
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, query, req.PostId).Scan(&deletedAt)
 	if err != nil {
 		fmt.Printf("Failed to delete post: %v\n", err)
-		return nil, fmt.Errorf("failed to delete post: %v", err)
+		return nil, dbError("failed to delete post", err)
+	}
+	err = addEvent(ctx, tx, req.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_PostTrashed{PostTrashed: &events.PostTrashed{
+			PurgeAt: timestamppb.New(deletedAt.Add(s.App.TrashRetention)),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
 	}
 
 	return &posts.DeletePostResponse{Success: true}, nil
 }
 
+// updatablePostFields are the paths UpdatePost accepts in its update mask.
+var updatablePostFields = []string{"title", "description", "is_private", "tags", "attachment_ids"}
+
+// replacedPostFields are the fields an empty update mask selects. Attachments
+// are left out so clients replacing a post without knowing about them do not
+// detach them.
+var replacedPostFields = []string{"title", "description", "is_private", "tags"}
+
+// updatedPostFields returns the set of fields an update mask selects. An empty
+// mask selects replacedPostFields.
+func updatedPostFields(mask *fieldmaskpb.FieldMask) (map[string]bool, error) {
+	fields := make(map[string]bool, len(updatablePostFields))
+	if len(mask.GetPaths()) == 0 {
+		for _, field := range replacedPostFields {
+			fields[field] = true
+		}
+		return fields, nil
+	}
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(updatablePostFields, path) {
+			return nil, invalidArgument("update_mask", fmt.Sprintf("unknown field %q, expected one of %s", path, strings.Join(updatablePostFields, ", ")))
+		}
+		fields[path] = true
+	}
+	return fields, nil
+}
+
 func (s *PostServiceServer) UpdatePost(ctx context.Context, req *posts.UpdatePostRequest) (*posts.UpdatePostResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		fmt.Printf("Failed to get metadata from context\n")
-		return nil, fmt.Errorf("failed to get metadata from context")
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
-	actorUserId := md.Get("actor_user_id")[0]
 
-	query := `SELECT creator_id FROM posts WHERE post_id = $1`
-	row := s.App.DB.QueryRow(ctx, query, req.PostId)
+	fields, err := updatedPostFields(req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	if fields["tags"] {
+		tags, err = normalizeTags(req.Tags)
+		if err != nil {
+			fmt.Printf("Invalid tags: %v\n", err)
+			return nil, invalidArgument("tags", err.Error())
+		}
+	}
+	if fields["attachment_ids"] {
+		if err := checkAttachmentIds(req.AttachmentIds); err != nil {
+			return nil, err
+		}
+	}
 
-	var creatorId string
-	err := row.Scan(&creatorId)
+	tx, err := s.App.DB.Begin(ctx)
 	if err != nil {
-		fmt.Printf("Failed to fetch post: %v\n", err)
-		return nil, fmt.Errorf("failed to fetch post: %v", err)
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
 	}
-	if actorUserId != creatorId {
-		fmt.Printf("Unauthorized: actor does not match creator\n")
-		return nil, fmt.Errorf("unauthorized: actor does not match creator")
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
 	}
 
-	// Implement logic to update a post in the database
-	query = `UPDATE posts SET title = $1, description = $2, is_private = $3 WHERE post_id = $4 RETURNING updated_at`
-	row = s.App.DB.QueryRow(ctx, query, req.Title, req.Description, req.IsPrivate, req.PostId)
+	query := `SELECT creator_id, title, description, is_private, updated_at FROM posts WHERE post_id = $1 AND deleted_at IS NULL FOR UPDATE`
+	row := tx.QueryRow(ctx, query, req.PostId)
 
 	var post posts.Post
+	var lastUpdatedAt time.Time
+	err = row.Scan(&post.CreatorId, &post.Title, &post.Description, &post.IsPrivate, &lastUpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, notFound("post", req.PostId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch post: %v\n", err)
+		return nil, dbError("failed to fetch post", err)
+	}
+	if actorUserId != post.CreatorId {
+		fmt.Printf("Unauthorized: actor does not match creator\n")
+		return nil, permissionDenied("NOT_POST_CREATOR", "actor does not match creator")
+	}
+	if req.ExpectedUpdatedAt != nil && !req.ExpectedUpdatedAt.AsTime().Equal(lastUpdatedAt) {
+		return nil, failedPrecondition("POST_MODIFIED", "post was modified since it was read")
+	}
+
 	post.PostId = req.PostId
-	post.Title = req.Title
-	post.Description = req.Description
-	post.IsPrivate = req.IsPrivate
+	if fields["title"] {
+		post.Title = req.Title
+	}
+	if fields["description"] {
+		post.Description = req.Description
+	}
+	if fields["is_private"] {
+		post.IsPrivate = req.IsPrivate
+	}
 
-	var updatedAt time.Time
-	err = row.Scan(&updatedAt)
+	// The row is updated even if only tags change, so updated_at moves and
+	// concurrent editors notice the change.
+	query = `UPDATE posts SET title = $1, description = $2, is_private = $3, revision = revision + 1
+			 WHERE post_id = $4
+			 RETURNING created_at, updated_at, revision, status, publish_at`
+	row = tx.QueryRow(ctx, query, post.Title, post.Description, post.IsPrivate, req.PostId)
+
+	var createdAt, updatedAt time.Time
+	var status string
+	var publishAt *time.Time
+	err = row.Scan(&createdAt, &updatedAt, &post.Revision, &status, &publishAt)
 	if err != nil {
 		fmt.Printf("Failed to update post: %v\n", err)
-		return nil, fmt.Errorf("failed to update post: %v", err)
+		return nil, dbError("failed to update post", err)
 	}
+	post.CreatedAt = timestamppb.New(createdAt)
 	post.UpdatedAt = timestamppb.New(updatedAt)
+	post.Status = postStatusFromName(status)
+	if publishAt != nil {
+		post.PublishAt = timestamppb.New(*publishAt)
+	}
+	post.Edited = true
+	if err := addRevision(ctx, tx, &post, actorUserId); err != nil {
+		return nil, err
+	}
+
+	if fields["tags"] {
+		if err := setPostTags(ctx, tx, post.PostId, tags); err != nil {
+			return nil, err
+		}
+		post.Tags = tags
+	} else if err := s.fillTags(ctx, []*posts.Post{&post}); err != nil {
+		return nil, err
+	}
+	if fields["attachment_ids"] {
+		if err := setPostAttachments(ctx, tx, post.PostId, actorUserId, req.AttachmentIds); err != nil {
+			return nil, err
+		}
+	}
+	err = addEvent(ctx, tx, post.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_PostUpdated{PostUpdated: &events.PostUpdated{
+			Title:     post.Title,
+			IsPrivate: post.IsPrivate,
+			Tags:      post.Tags,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	if err := s.fillReactions(ctx, []*posts.Post{&post}, actorUserId); err != nil {
+		return nil, err
+	}
+	if err := s.fillAttachments(ctx, []*posts.Post{&post}); err != nil {
+		return nil, err
+	}
 
 	return &posts.UpdatePostResponse{Post: &post}, nil
 }
 
-func (s *PostServiceServer) GetPostById(ctx context.Context, req *posts.GetPostByIdRequest) (*posts.GetPostByIdResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		fmt.Printf("Failed to get metadata from context\n")
-		return nil, fmt.Errorf("failed to get metadata from context")
+// fillPostDetails loads everything a Post carries besides its own columns.
+func (s *PostServiceServer) fillPostDetails(ctx context.Context, postsList []*posts.Post, actorUserId string) error {
+	if err := s.fillTags(ctx, postsList); err != nil {
+		return err
 	}
-	actorUserId := md.Get("actor_user_id")[0]
-
-	query := `SELECT creator_id, is_private FROM posts WHERE post_id = $1`
-	row := s.App.DB.QueryRow(ctx, query, req.PostId)
+	if err := s.fillAttachments(ctx, postsList); err != nil {
+		return err
+	}
+	return s.fillReactions(ctx, postsList, actorUserId)
+}
 
-	var creatorId string
-	var isPrivate bool
-	err := row.Scan(&creatorId, &isPrivate)
+func (s *PostServiceServer) GetPostById(ctx context.Context, req *posts.GetPostByIdRequest) (*posts.GetPostByIdResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
 	if err != nil {
-		fmt.Printf("Failed to fetch post: %v\n", err)
-		return nil, fmt.Errorf("failed to fetch post: %v", err)
+		return nil, err
 	}
-	if isPrivate && actorUserId != creatorId {
-		fmt.Printf("Unauthorized: actor does not have access to private post\n")
-		return nil, fmt.Errorf("unauthorized: actor does not have access to private post")
+
+	if err := s.checkPostAccess(ctx, req.PostId, actorUserId); err != nil {
+		return nil, err
 	}
 
 	// Implement logic to fetch a post by ID from the database
-	query = `SELECT post_id, title, description, creator_id, created_at, updated_at, is_private FROM posts WHERE post_id = $1`
-	row = s.App.DB.QueryRow(ctx, query, req.PostId)
-
-	var post posts.Post
-	var createdAt, updatedAt time.Time
-	err = row.Scan(&post.PostId, &post.Title, &post.Description, &post.CreatorId, &createdAt, &updatedAt, &post.IsPrivate)
+	query := `SELECT ` + postColumns + ` FROM posts WHERE post_id = $1`
+	post, err := scanPost(s.App.DB.QueryRow(ctx, query, req.PostId))
+	if err == pgx.ErrNoRows {
+		return nil, notFound("post", req.PostId)
+	}
 	if err != nil {
 		fmt.Printf("Failed to fetch post by ID: %v\n", err)
-		return nil, fmt.Errorf("failed to fetch post by ID: %v", err)
+		return nil, dbError("failed to fetch post by ID", err)
+	}
+
+	if err := s.fillPostDetails(ctx, []*posts.Post{post}, actorUserId); err != nil {
+		return nil, err
 	}
-	post.CreatedAt = timestamppb.New(createdAt)
-	post.UpdatedAt = timestamppb.New(updatedAt)
 
-	return &posts.GetPostByIdResponse{Post: &post}, nil
+	return &posts.GetPostByIdResponse{Post: post}, nil
 }
 
 func (s *PostServiceServer) GetPosts(ctx context.Context, req *posts.GetPostsRequest) (*posts.GetPostsResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		fmt.Printf("Failed to get metadata from context\n")
-		return nil, fmt.Errorf("failed to get metadata from context")
-	}
-	actorUserId := md.Get("actor_user_id")[0]
-	query := `SELECT post_id, title, description, creator_id, created_at, updated_at, is_private 
-			  FROM posts 
-			  ORDER BY created_at ASC 
-			  LIMIT $1`
-	startFrom := time.Unix(0, 0)
-	if req.StartFrom != nil {
-		startFrom = req.StartFrom.AsTime()
-	}
-	_, _ = actorUserId, startFrom
-	rows, err := s.App.DB.Query(ctx, query, req.Limit)
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		fmt.Printf("Invalid tags: %v\n", err)
+		return nil, invalidArgument("tags", err.Error())
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultPostsLimit
+	}
+	if limit > maxPostsLimit {
+		limit = maxPostsLimit
+	}
+
+	// The filter is shared by the count, page and boundary queries; its
+	// placeholders are the first filterArgs arguments.
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions := []string{listedPostCondition("posts", arg(actorUserId))}
+	if len(tags) > 0 {
+		conditions = append(conditions, tagFilterCondition(req.TagMatch, arg(tags)))
+	}
+	filter := strings.Join(conditions, " AND ")
+	filterArgs := len(args)
+
+	var totalCount int32
+	err = s.App.DB.QueryRow(ctx, `SELECT COUNT(*) FROM posts WHERE `+filter, args...).Scan(&totalCount)
+	if err != nil {
+		fmt.Printf("Failed to count posts: %v\n", err)
+		return nil, dbError("failed to count posts", err)
+	}
+
+	backward := false
+	position := "TRUE"
+	if req.PageToken != "" {
+		var at time.Time
+		var id string
+		backward, at, id, err = decodePageToken(req.PageToken)
+		if err != nil {
+			fmt.Printf("Failed to decode page token: %v\n", err)
+			return nil, invalidArgument("page_token", err.Error())
+		}
+		op := ">"
+		if backward {
+			op = "<"
+		}
+		position = fmt.Sprintf("(%s, post_id) %s (%s, %s)", postListedAt, op, arg(at), arg(id))
+	} else if req.StartFrom != nil {
+		position = postListedAt + " >= " + arg(req.StartFrom.AsTime())
+	}
+	order := "ASC"
+	if backward {
+		order = "DESC"
+	}
+
+	// Fetch one extra row to find out whether the page is the last one in
+	// its direction.
+	query := `SELECT ` + postColumns + `
+			  FROM posts
+			  WHERE ` + filter + ` AND ` + position + `
+			  ORDER BY ` + postListedAt + ` ` + order + `, post_id ` + order + `
+			  LIMIT ` + arg(limit+1)
+	rows, err := s.App.DB.Query(ctx, query, args...)
 	if err != nil {
 		fmt.Printf("Failed to fetch posts: %v\n", err)
-		return nil, fmt.Errorf("failed to fetch posts: %v", err)
+		return nil, dbError("failed to fetch posts", err)
 	}
 	defer rows.Close()
 
-	var postsList []*posts.Post
+	postsList := []*posts.Post{}
 	for rows.Next() {
-		var post posts.Post
-		var createdAt, updatedAt time.Time
-		err := rows.Scan(&post.PostId, &post.Title, &post.Description, &post.CreatorId, &createdAt, &updatedAt, &post.IsPrivate)
+		post, err := scanPost(rows)
 		if err != nil {
 			fmt.Printf("Failed to scan post: %v\n", err)
-			return nil, fmt.Errorf("failed to scan post: %v", err)
+			return nil, dbError("failed to scan post", err)
 		}
-		post.CreatedAt = timestamppb.New(createdAt)
-		post.UpdatedAt = timestamppb.New(updatedAt)
-		postsList = append(postsList, &post)
+		postsList = append(postsList, post)
 	}
 
 	if err = rows.Err(); err != nil {
 		fmt.Printf("Error iterating over rows: %v\n", err)
-		return nil, fmt.Errorf("error iterating over rows: %v", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	hasExtra := len(postsList) > limit
+	if hasExtra {
+		postsList = postsList[:limit]
+	}
+	if backward {
+		slices.Reverse(postsList)
+	}
+
+	resp := &posts.GetPostsResponse{TotalCount: totalCount}
+	if len(postsList) > 0 {
+		first, last := postsList[0], postsList[len(postsList)-1]
+		// The extra row answers whether there is more in the direction we
+		// paged; the opposite side needs a separate check.
+		hasBefore, hasAfter := hasExtra, hasExtra
+		if backward {
+			hasAfter, err = s.postExistsBeyond(ctx, filter, args[:filterArgs], ">", last)
+		} else {
+			hasBefore, err = s.postExistsBeyond(ctx, filter, args[:filterArgs], "<", first)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hasAfter {
+			resp.NextPageToken = encodePageToken(false, listedAt(last), last.PostId)
+		}
+		if hasBefore {
+			resp.PrevPageToken = encodePageToken(true, listedAt(first), first.PostId)
+		}
+		resp.HasMore = hasAfter
 	}
 
-	// len of postsList
-	var totalCount int32 = int32(len(postsList))
+	if err := s.fillPostDetails(ctx, postsList, actorUserId); err != nil {
+		return nil, err
+	}
+	resp.Posts = postsList
 
-	return &posts.GetPostsResponse{Posts: postsList, TotalCount: totalCount}, nil
+	return resp, nil
+}
+
+// postExistsBeyond reports whether a post matching filter sorts before ("<")
+// or after (">") the given post.
+func (s *PostServiceServer) postExistsBeyond(ctx context.Context, filter string, filterArgs []any, op string, post *posts.Post) (bool, error) {
+	args := append(slices.Clip(filterArgs), listedAt(post), post.PostId)
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM posts WHERE %s AND (%s, post_id) %s ($%d, $%d))`,
+		filter, postListedAt, op, len(args)-1, len(args))
+	var exists bool
+	err := s.App.DB.QueryRow(ctx, query, args...).Scan(&exists)
+	if err != nil {
+		fmt.Printf("Failed to check for more posts: %v\n", err)
+		return false, dbError("failed to check for more posts", err)
+	}
+	return exists, nil
 }
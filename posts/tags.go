@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	maxTagsPerPost         = 10
+	maxTagLength           = 64
+	defaultPopularTagLimit = 20
+	maxPopularTagLimit     = 100
+)
+
+// normalizeTags lowercases tags, strips a leading '#', and drops empty and
+// duplicate entries while keeping the original order.
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > maxTagLength {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", tag, maxTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTagsPerPost {
+		return nil, fmt.Errorf("a post can have at most %d tags", maxTagsPerPost)
+	}
+	return normalized, nil
+}
+
+// setPostTags replaces the tags of a post, creating missing tags. tags must
+// already be normalized.
+func setPostTags(ctx context.Context, tx pgx.Tx, postId string, tags []string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM posts_tags WHERE post_id = $1`, postId)
+	if err != nil {
+		fmt.Printf("Failed to clear post tags: %v\n", err)
+		return dbError("failed to clear post tags", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING`, tags)
+	if err != nil {
+		fmt.Printf("Failed to upsert tags: %v\n", err)
+		return dbError("failed to upsert tags", err)
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO posts_tags (post_id, tag_id) SELECT $1, id FROM tags WHERE name = ANY($2)`, postId, tags)
+	if err != nil {
+		fmt.Printf("Failed to set post tags: %v\n", err)
+		return dbError("failed to set post tags", err)
+	}
+	return nil
+}
+
+// fillTags sets the tags of every post in postsList using a single query.
+func (s *PostServiceServer) fillTags(ctx context.Context, postsList []*posts.Post) error {
+	if len(postsList) == 0 {
+		return nil
+	}
+	byId := make(map[string]*posts.Post, len(postsList))
+	postIds := make([]string, 0, len(postsList))
+	for _, post := range postsList {
+		post.Tags = []string{}
+		byId[post.PostId] = post
+		postIds = append(postIds, post.PostId)
+	}
+
+	query := `SELECT pt.post_id, t.name FROM posts_tags pt JOIN tags t ON t.id = pt.tag_id
+			  WHERE pt.post_id = ANY($1)
+			  ORDER BY t.name`
+	rows, err := s.App.DB.Query(ctx, query, postIds)
+	if err != nil {
+		fmt.Printf("Failed to fetch tags: %v\n", err)
+		return dbError("failed to fetch tags", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var postId, name string
+		if err := rows.Scan(&postId, &name); err != nil {
+			fmt.Printf("Failed to scan tag: %v\n", err)
+			return dbError("failed to scan tag", err)
+		}
+		byId[postId].Tags = append(byId[postId].Tags, name)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return dbError("error iterating over rows", err)
+	}
+	return nil
+}
+
+// tagFilterCondition returns an SQL condition on posts.post_id matching the
+// tags bound to the given placeholder.
+func tagFilterCondition(match posts.TagMatch, placeholder string) string {
+	if match == posts.TagMatch_TAG_MATCH_ALL {
+		return fmt.Sprintf(`(SELECT COUNT(*) FROM posts_tags pt JOIN tags t ON t.id = pt.tag_id
+				WHERE pt.post_id = posts.post_id AND t.name = ANY(%[1]s)) = cardinality(%[1]s::text[])`, placeholder)
+	}
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM posts_tags pt JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.post_id = posts.post_id AND t.name = ANY(%s))`, placeholder)
+}
+
+func (s *PostServiceServer) ListPopularTags(ctx context.Context, req *posts.ListPopularTagsRequest) (*posts.ListPopularTagsResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPopularTagLimit
+	}
+	if limit > maxPopularTagLimit {
+		limit = maxPopularTagLimit
+	}
+
+	// Only posts the actor can see are counted, so private posts do not leak
+	// through tag statistics.
+	query := `SELECT t.name, COUNT(*) AS post_count
+			  FROM tags t
+			  JOIN posts_tags pt ON pt.tag_id = t.id
+			  JOIN posts p ON p.post_id = pt.post_id
+			  WHERE ` + listedPostCondition("p", "$1") + `
+			  GROUP BY t.name
+			  ORDER BY post_count DESC, t.name ASC
+			  LIMIT $2`
+	rows, err := s.App.DB.Query(ctx, query, actorUserId, limit)
+	if err != nil {
+		fmt.Printf("Failed to fetch popular tags: %v\n", err)
+		return nil, dbError("failed to fetch popular tags", err)
+	}
+	defer rows.Close()
+
+	tags := []*posts.TagCount{}
+	for rows.Next() {
+		var tag posts.TagCount
+		if err := rows.Scan(&tag.Name, &tag.PostCount); err != nil {
+			fmt.Printf("Failed to scan tag: %v\n", err)
+			return nil, dbError("failed to scan tag", err)
+		}
+		tags = append(tags, &tag)
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	return &posts.ListPopularTagsResponse{Tags: tags}, nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/events"
+	"msg.i3cheese.ru/proto/posts"
+)
+
+const (
+	defaultTrashRetention = 30 * 24 * time.Hour
+	defaultPurgeInterval  = time.Hour
+	purgeBatchSize        = 100
+
+	defaultDeletedPostsLimit = 20
+	maxDeletedPostsLimit     = 100
+)
+
+// restorableCondition matches posts in the trash whose retention window,
+// given in seconds by the placeholder, has not passed yet.
+func restorableCondition(retentionPlaceholder string) string {
+	return fmt.Sprintf("(deleted_at IS NOT NULL AND deleted_at > CURRENT_TIMESTAMP - make_interval(secs => %s))", retentionPlaceholder)
+}
+
+func (s *PostServiceServer) ListDeletedPosts(ctx context.Context, req *posts.ListDeletedPostsRequest) (*posts.ListDeletedPostsResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultDeletedPostsLimit
+	}
+	if limit > maxDeletedPostsLimit {
+		limit = maxDeletedPostsLimit
+	}
+
+	var beforeDeletedAt *time.Time
+	var beforePostId string
+	if req.Cursor != "" {
+		deletedAt, postId, err := decodeCursor(req.Cursor)
+		if err != nil {
+			fmt.Printf("Failed to decode cursor: %v\n", err)
+			return nil, invalidArgument("cursor", err.Error())
+		}
+		beforeDeletedAt, beforePostId = &deletedAt, postId
+	}
+
+	// Fetch one extra row to find out whether there is a next page.
+	query := `SELECT ` + postColumns + `, deleted_at
+			  FROM posts
+			  WHERE creator_id = $1
+			    AND ` + restorableCondition("$2") + `
+			    AND ($3::timestamp IS NULL OR (deleted_at, post_id) < ($3, $4))
+			  ORDER BY deleted_at DESC, post_id DESC
+			  LIMIT $5`
+	rows, err := s.App.DB.Query(ctx, query, actorUserId, s.App.TrashRetention.Seconds(), beforeDeletedAt, beforePostId, limit+1)
+	if err != nil {
+		fmt.Printf("Failed to fetch deleted posts: %v\n", err)
+		return nil, dbError("failed to fetch deleted posts", err)
+	}
+	defer rows.Close()
+
+	deleted := []*posts.DeletedPost{}
+	for rows.Next() {
+		var deletedAt time.Time
+		post, err := scanPost(rowScanner(func(dest ...any) error {
+			return rows.Scan(append(dest, &deletedAt)...)
+		}))
+		if err != nil {
+			fmt.Printf("Failed to scan post: %v\n", err)
+			return nil, dbError("failed to scan post", err)
+		}
+		deleted = append(deleted, &posts.DeletedPost{
+			Post:      post,
+			DeletedAt: timestamppb.New(deletedAt),
+			PurgeAt:   timestamppb.New(deletedAt.Add(s.App.TrashRetention)),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	var nextCursor string
+	if len(deleted) > int(limit) {
+		deleted = deleted[:limit]
+		last := deleted[len(deleted)-1]
+		nextCursor = encodeCursor(last.DeletedAt.AsTime(), last.Post.PostId)
+	}
+
+	postsList := make([]*posts.Post, 0, len(deleted))
+	for _, d := range deleted {
+		postsList = append(postsList, d.Post)
+	}
+	if err := s.fillPostDetails(ctx, postsList, actorUserId); err != nil {
+		return nil, err
+	}
+
+	return &posts.ListDeletedPostsResponse{Posts: deleted, NextCursor: nextCursor}, nil
+}
+
+// RestorePost takes a post of the caller out of the trash. Posts of other
+// users and posts past the retention window are reported as NotFound.
+func (s *PostServiceServer) RestorePost(ctx context.Context, req *posts.RestorePostRequest) (*posts.RestorePostResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.App.DB.Begin(ctx)
+	if err != nil {
+		fmt.Printf("Failed to begin transaction: %v\n", err)
+		return nil, dbError("failed to begin transaction", err)
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, req.PostId); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE posts SET deleted_at = NULL
+			  WHERE post_id = $1 AND creator_id = $2 AND ` + restorableCondition("$3") + `
+			  RETURNING ` + postColumns
+	post, err := scanPost(tx.QueryRow(ctx, query, req.PostId, actorUserId, s.App.TrashRetention.Seconds()))
+	if err == pgx.ErrNoRows {
+		return nil, notFound("deleted post", req.PostId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to restore post: %v\n", err)
+		return nil, dbError("failed to restore post", err)
+	}
+	err = addEvent(ctx, tx, req.PostId, actorUserId, &events.PostEvent{
+		Payload: &events.PostEvent_PostRestored{PostRestored: &events.PostRestored{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		fmt.Printf("Failed to commit transaction: %v\n", err)
+		return nil, dbError("failed to commit transaction", err)
+	}
+
+	if err := s.fillPostDetails(ctx, []*posts.Post{post}, actorUserId); err != nil {
+		return nil, err
+	}
+
+	return &posts.RestorePostResponse{Post: post}, nil
+}
+
+// PostPurger removes posts that have been in the trash for longer than
+// Retention, together with their tags, comments, reactions and revisions, and
+// publishes a PostDeleted event for each. Every post is removed in its own
+// transaction, so several instances can purge at the same time.
+type PostPurger struct {
+	DB        *pgxpool.Pool
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+func (p *PostPurger) Run(ctx context.Context) {
+	for {
+		purged, err := p.purgeBatch(ctx)
+		if err != nil {
+			fmt.Printf("Failed to purge posts: %v\n", err)
+		}
+		// Keep going while there is a backlog.
+		if err == nil && purged == purgeBatchSize {
+			continue
+		}
+		select {
+		case <-time.After(p.Interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// purgeBatch purges up to purgeBatchSize expired posts and returns how many
+// it found.
+func (p *PostPurger) purgeBatch(ctx context.Context) (int, error) {
+	query := `SELECT post_id FROM posts
+			  WHERE deleted_at <= CURRENT_TIMESTAMP - make_interval(secs => $1)
+			  ORDER BY deleted_at
+			  LIMIT $2`
+	rows, err := p.DB.Query(ctx, query, p.Retention.Seconds(), purgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	postIds, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, err
+	}
+
+	for _, postId := range postIds {
+		if err := p.purgePost(ctx, postId); err != nil {
+			return 0, err
+		}
+	}
+	return len(postIds), nil
+}
+
+// purgePost removes the post if it is still expired; it may have been
+// restored or purged by another instance since it was selected.
+func (p *PostPurger) purgePost(ctx context.Context, postId string) error {
+	tx, err := p.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	if err := lockPostEvents(ctx, tx, postId); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM posts
+			  WHERE post_id = $1 AND deleted_at <= CURRENT_TIMESTAMP - make_interval(secs => $2)
+			  RETURNING creator_id`
+	var creatorId string
+	err = tx.QueryRow(ctx, query, postId, p.Retention.Seconds()).Scan(&creatorId)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	err = addEvent(ctx, tx, postId, creatorId, &events.PostEvent{
+		Payload: &events.PostEvent_PostDeleted{PostDeleted: &events.PostDeleted{}},
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
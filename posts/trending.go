@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"msg.i3cheese.ru/proto/posts"
+	"msg.i3cheese.ru/proto/statistics"
+)
+
+const (
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = 100
+	// trendingOverfetch is how many times more candidates than requested are
+	// asked from statistics, since private and deleted posts are dropped.
+	trendingOverfetch = 3
+)
+
+// GetTrending returns public posts in the order ranked by the statistics
+// service. The ranking is cached there, so this costs one call and one
+// lookup of the candidates.
+func (s *PostServiceServer) GetTrending(ctx context.Context, req *posts.GetTrendingRequest) (*posts.GetTrendingResponse, error) {
+	actorUserId, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+
+	if s.App.Statistics == nil {
+		return nil, status.Error(codes.Unavailable, "trending posts are not configured")
+	}
+	trending, err := s.App.Statistics.GetTrendingPosts(ctx, &statistics.GetTrendingPostsRequest{Limit: int32(limit * trendingOverfetch)})
+	if err != nil {
+		fmt.Printf("Failed to fetch trending posts: %v\n", err)
+		return nil, status.Errorf(codes.Unavailable, "failed to fetch trending posts: %v", err)
+	}
+	postIds := make([]string, 0, len(trending.Posts))
+	for _, t := range trending.Posts {
+		postIds = append(postIds, t.PostId)
+	}
+
+	query := `SELECT ` + postColumns + ` FROM posts WHERE post_id = ANY($1) AND ` + publicPostCondition("posts")
+	rows, err := s.App.DB.Query(ctx, query, postIds)
+	if err != nil {
+		fmt.Printf("Failed to fetch trending posts: %v\n", err)
+		return nil, dbError("failed to fetch trending posts", err)
+	}
+	defer rows.Close()
+
+	byId := make(map[string]*posts.Post, len(postIds))
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			fmt.Printf("Failed to scan post: %v\n", err)
+			return nil, dbError("failed to scan post", err)
+		}
+		byId[post.PostId] = post
+	}
+	if err = rows.Err(); err != nil {
+		fmt.Printf("Error iterating over rows: %v\n", err)
+		return nil, dbError("error iterating over rows", err)
+	}
+
+	postsList := []*posts.Post{}
+	for _, postId := range postIds {
+		if post, ok := byId[postId]; ok && len(postsList) < limit {
+			postsList = append(postsList, post)
+		}
+	}
+	if err := s.fillPostDetails(ctx, postsList, actorUserId); err != nil {
+		return nil, err
+	}
+	return &posts.GetTrendingResponse{Posts: postsList}, nil
+}
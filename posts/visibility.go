@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// visiblePostCondition is the single definition of who may read a post:
+// published public posts are visible to everyone, private ones, drafts and
+// scheduled posts only to their creator, and posts in the trash to no one.
+// alias names the posts table in the surrounding query and actorPlaceholder
+// is the placeholder bound to the actor's user id. Every read path must
+// filter with it rather than re-implementing the rule.
+func visiblePostCondition(alias string, actorPlaceholder string) string {
+	return fmt.Sprintf("(%[1]s.deleted_at IS NULL AND (%[1]s.creator_id = %[2]s OR (%[1]s.status = 'published' AND NOT COALESCE(%[1]s.is_private, FALSE))))", alias, actorPlaceholder)
+}
+
+// listedPostCondition is visiblePostCondition for listings, which only show
+// published posts; the creator finds their drafts with ListMyDrafts.
+func listedPostCondition(alias string, actorPlaceholder string) string {
+	return fmt.Sprintf("(%s AND %s.status = 'published')", visiblePostCondition(alias, actorPlaceholder), alias)
+}
+
+// checkPostAccess fails if the post does not exist or is not visible to the
+// actor. Both cases are reported as NotFound so that private posts can not be
+// probed for.
+func (s *PostServiceServer) checkPostAccess(ctx context.Context, postId string, actorUserId string) error {
+	query := `SELECT ` + visiblePostCondition("p", "$2") + ` FROM posts p WHERE p.post_id = $1`
+	row := s.App.DB.QueryRow(ctx, query, postId, actorUserId)
+
+	var visible bool
+	err := row.Scan(&visible)
+	if err == pgx.ErrNoRows {
+		return notFound("post", postId)
+	}
+	if err != nil {
+		fmt.Printf("Failed to fetch post: %v\n", err)
+		return dbError("failed to fetch post", err)
+	}
+	if !visible {
+		fmt.Printf("Unauthorized: actor does not have access to private post\n")
+		return notFound("post", postId)
+	}
+	return nil
+}
+
+// publicPostCondition matches posts visible to everyone, for listings that do
+// not depend on who is asking, such as trending.
+func publicPostCondition(alias string) string {
+	return fmt.Sprintf("(%[1]s.deleted_at IS NULL AND %[1]s.status = 'published' AND NOT COALESCE(%[1]s.is_private, FALSE))", alias)
+}
@@ -0,0 +1,1069 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: events.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PostEvent is published by the posts service for every change to a post,
+// its comments or its reactions. Events are keyed by post_id, so events of
+// one post are consumed in the order they happened. Delivery is at least
+// once: consumers must ignore an event_id they have already seen.
+type PostEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EventId    string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	PostId     string                 `protobuf:"bytes,3,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	// User who made the change.
+	ActorUserId string `protobuf:"bytes,4,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*PostEvent_PostCreated
+	//	*PostEvent_PostUpdated
+	//	*PostEvent_PostDeleted
+	//	*PostEvent_CommentCreated
+	//	*PostEvent_CommentUpdated
+	//	*PostEvent_CommentDeleted
+	//	*PostEvent_ReactionSet
+	//	*PostEvent_ReactionRemoved
+	//	*PostEvent_PostTrashed
+	//	*PostEvent_PostRestored
+	//	*PostEvent_PostPublished
+	Payload       isPostEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostEvent) Reset() {
+	*x = PostEvent{}
+	mi := &file_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostEvent) ProtoMessage() {}
+
+func (x *PostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostEvent.ProtoReflect.Descriptor instead.
+func (*PostEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PostEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PostEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostEvent) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *PostEvent) GetPayload() isPostEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPostCreated() *PostCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_PostCreated); ok {
+			return x.PostCreated
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPostUpdated() *PostUpdated {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_PostUpdated); ok {
+			return x.PostUpdated
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPostDeleted() *PostDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_PostDeleted); ok {
+			return x.PostDeleted
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetCommentCreated() *CommentCreated {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_CommentCreated); ok {
+			return x.CommentCreated
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetCommentUpdated() *CommentUpdated {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_CommentUpdated); ok {
+			return x.CommentUpdated
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetCommentDeleted() *CommentDeleted {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_CommentDeleted); ok {
+			return x.CommentDeleted
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetReactionSet() *ReactionSet {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_ReactionSet); ok {
+			return x.ReactionSet
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetReactionRemoved() *ReactionRemoved {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_ReactionRemoved); ok {
+			return x.ReactionRemoved
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPostTrashed() *PostTrashed {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_PostTrashed); ok {
+			return x.PostTrashed
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPostRestored() *PostRestored {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_PostRestored); ok {
+			return x.PostRestored
+		}
+	}
+	return nil
+}
+
+func (x *PostEvent) GetPostPublished() *PostPublished {
+	if x != nil {
+		if x, ok := x.Payload.(*PostEvent_PostPublished); ok {
+			return x.PostPublished
+		}
+	}
+	return nil
+}
+
+type isPostEvent_Payload interface {
+	isPostEvent_Payload()
+}
+
+type PostEvent_PostCreated struct {
+	PostCreated *PostCreated `protobuf:"bytes,10,opt,name=post_created,json=postCreated,proto3,oneof"`
+}
+
+type PostEvent_PostUpdated struct {
+	PostUpdated *PostUpdated `protobuf:"bytes,11,opt,name=post_updated,json=postUpdated,proto3,oneof"`
+}
+
+type PostEvent_PostDeleted struct {
+	PostDeleted *PostDeleted `protobuf:"bytes,12,opt,name=post_deleted,json=postDeleted,proto3,oneof"`
+}
+
+type PostEvent_CommentCreated struct {
+	CommentCreated *CommentCreated `protobuf:"bytes,13,opt,name=comment_created,json=commentCreated,proto3,oneof"`
+}
+
+type PostEvent_CommentUpdated struct {
+	CommentUpdated *CommentUpdated `protobuf:"bytes,14,opt,name=comment_updated,json=commentUpdated,proto3,oneof"`
+}
+
+type PostEvent_CommentDeleted struct {
+	CommentDeleted *CommentDeleted `protobuf:"bytes,15,opt,name=comment_deleted,json=commentDeleted,proto3,oneof"`
+}
+
+type PostEvent_ReactionSet struct {
+	ReactionSet *ReactionSet `protobuf:"bytes,16,opt,name=reaction_set,json=reactionSet,proto3,oneof"`
+}
+
+type PostEvent_ReactionRemoved struct {
+	ReactionRemoved *ReactionRemoved `protobuf:"bytes,17,opt,name=reaction_removed,json=reactionRemoved,proto3,oneof"`
+}
+
+type PostEvent_PostTrashed struct {
+	PostTrashed *PostTrashed `protobuf:"bytes,18,opt,name=post_trashed,json=postTrashed,proto3,oneof"`
+}
+
+type PostEvent_PostRestored struct {
+	PostRestored *PostRestored `protobuf:"bytes,19,opt,name=post_restored,json=postRestored,proto3,oneof"`
+}
+
+type PostEvent_PostPublished struct {
+	PostPublished *PostPublished `protobuf:"bytes,20,opt,name=post_published,json=postPublished,proto3,oneof"`
+}
+
+func (*PostEvent_PostCreated) isPostEvent_Payload() {}
+
+func (*PostEvent_PostUpdated) isPostEvent_Payload() {}
+
+func (*PostEvent_PostDeleted) isPostEvent_Payload() {}
+
+func (*PostEvent_CommentCreated) isPostEvent_Payload() {}
+
+func (*PostEvent_CommentUpdated) isPostEvent_Payload() {}
+
+func (*PostEvent_CommentDeleted) isPostEvent_Payload() {}
+
+func (*PostEvent_ReactionSet) isPostEvent_Payload() {}
+
+func (*PostEvent_ReactionRemoved) isPostEvent_Payload() {}
+
+func (*PostEvent_PostTrashed) isPostEvent_Payload() {}
+
+func (*PostEvent_PostRestored) isPostEvent_Payload() {}
+
+func (*PostEvent_PostPublished) isPostEvent_Payload() {}
+
+type PostCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatorId     string                 `protobuf:"bytes,1,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	IsPrivate     bool                   `protobuf:"varint,3,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostCreated) Reset() {
+	*x = PostCreated{}
+	mi := &file_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostCreated) ProtoMessage() {}
+
+func (x *PostCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostCreated.ProtoReflect.Descriptor instead.
+func (*PostCreated) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostCreated) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *PostCreated) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PostCreated) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *PostCreated) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type PostUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	IsPrivate     bool                   `protobuf:"varint,2,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostUpdated) Reset() {
+	*x = PostUpdated{}
+	mi := &file_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostUpdated) ProtoMessage() {}
+
+func (x *PostUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostUpdated.ProtoReflect.Descriptor instead.
+func (*PostUpdated) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PostUpdated) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PostUpdated) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *PostUpdated) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// PostTrashed is emitted when the creator deletes the post. The post is
+// hidden but can still be restored until purge_at.
+type PostTrashed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgeAt       *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostTrashed) Reset() {
+	*x = PostTrashed{}
+	mi := &file_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostTrashed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostTrashed) ProtoMessage() {}
+
+func (x *PostTrashed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostTrashed.ProtoReflect.Descriptor instead.
+func (*PostTrashed) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PostTrashed) GetPurgeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return nil
+}
+
+// PostPublished is emitted when a post becomes visible to others: on
+// creation, or later for drafts and scheduled posts. actor_user_id is the
+// creator, also when the scheduler publishes the post.
+type PostPublished struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostPublished) Reset() {
+	*x = PostPublished{}
+	mi := &file_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostPublished) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostPublished) ProtoMessage() {}
+
+func (x *PostPublished) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostPublished.ProtoReflect.Descriptor instead.
+func (*PostPublished) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{4}
+}
+
+// PostRestored is emitted when the creator takes the post back out of the
+// trash.
+type PostRestored struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRestored) Reset() {
+	*x = PostRestored{}
+	mi := &file_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRestored) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRestored) ProtoMessage() {}
+
+func (x *PostRestored) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRestored.ProtoReflect.Descriptor instead.
+func (*PostRestored) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{5}
+}
+
+// PostDeleted is emitted when a post is removed for good, once it has been
+// in the trash for the retention window. actor_user_id is its creator.
+// Comments and reactions of the post are deleted with it and get no events
+// of their own.
+type PostDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostDeleted) Reset() {
+	*x = PostDeleted{}
+	mi := &file_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostDeleted) ProtoMessage() {}
+
+func (x *PostDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostDeleted.ProtoReflect.Descriptor instead.
+func (*PostDeleted) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{6}
+}
+
+type CommentCreated struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommentId string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	// Empty for a top-level comment.
+	ParentCommentId string `protobuf:"bytes,2,opt,name=parent_comment_id,json=parentCommentId,proto3" json:"parent_comment_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CommentCreated) Reset() {
+	*x = CommentCreated{}
+	mi := &file_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentCreated) ProtoMessage() {}
+
+func (x *CommentCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentCreated.ProtoReflect.Descriptor instead.
+func (*CommentCreated) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CommentCreated) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *CommentCreated) GetParentCommentId() string {
+	if x != nil {
+		return x.ParentCommentId
+	}
+	return ""
+}
+
+type CommentUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommentId     string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentUpdated) Reset() {
+	*x = CommentUpdated{}
+	mi := &file_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentUpdated) ProtoMessage() {}
+
+func (x *CommentUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentUpdated.ProtoReflect.Descriptor instead.
+func (*CommentUpdated) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CommentUpdated) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+type CommentDeleted struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommentId string                 `protobuf:"bytes,1,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	// Number of comments removed, including all replies in the thread.
+	DeletedCount  int32 `protobuf:"varint,2,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentDeleted) Reset() {
+	*x = CommentDeleted{}
+	mi := &file_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentDeleted) ProtoMessage() {}
+
+func (x *CommentDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentDeleted.ProtoReflect.Descriptor instead.
+func (*CommentDeleted) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CommentDeleted) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *CommentDeleted) GetDeletedCount() int32 {
+	if x != nil {
+		return x.DeletedCount
+	}
+	return 0
+}
+
+// ReactionSet is emitted when actor_user_id reacts to the post, including
+// when an earlier reaction is replaced.
+type ReactionSet struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ReactionType string                 `protobuf:"bytes,1,opt,name=reaction_type,json=reactionType,proto3" json:"reaction_type,omitempty"`
+	// Reaction that was replaced, empty if the user had none.
+	PreviousReactionType string `protobuf:"bytes,2,opt,name=previous_reaction_type,json=previousReactionType,proto3" json:"previous_reaction_type,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ReactionSet) Reset() {
+	*x = ReactionSet{}
+	mi := &file_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionSet) ProtoMessage() {}
+
+func (x *ReactionSet) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionSet.ProtoReflect.Descriptor instead.
+func (*ReactionSet) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReactionSet) GetReactionType() string {
+	if x != nil {
+		return x.ReactionType
+	}
+	return ""
+}
+
+func (x *ReactionSet) GetPreviousReactionType() string {
+	if x != nil {
+		return x.PreviousReactionType
+	}
+	return ""
+}
+
+type ReactionRemoved struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReactionType  string                 `protobuf:"bytes,1,opt,name=reaction_type,json=reactionType,proto3" json:"reaction_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionRemoved) Reset() {
+	*x = ReactionRemoved{}
+	mi := &file_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionRemoved) ProtoMessage() {}
+
+func (x *ReactionRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionRemoved.ProtoReflect.Descriptor instead.
+func (*ReactionRemoved) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReactionRemoved) GetReactionType() string {
+	if x != nil {
+		return x.ReactionType
+	}
+	return ""
+}
+
+// PostViewed is published by the gateway when a user opens a post. Repeated
+// views by one user within a short window are published once.
+type PostViewed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	PostId        string                 `protobuf:"bytes,3,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostViewed) Reset() {
+	*x = PostViewed{}
+	mi := &file_events_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostViewed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostViewed) ProtoMessage() {}
+
+func (x *PostViewed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostViewed.ProtoReflect.Descriptor instead.
+func (*PostViewed) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PostViewed) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PostViewed) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *PostViewed) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostViewed) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostViewed) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *PostViewed) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+var File_events_proto protoreflect.FileDescriptor
+
+const file_events_proto_rawDesc = "" +
+	"\n" +
+	"\fevents.proto\x12\fproto.events\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\a\n" +
+	"\tPostEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12;\n" +
+	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x17\n" +
+	"\apost_id\x18\x03 \x01(\tR\x06postId\x12\"\n" +
+	"\ractor_user_id\x18\x04 \x01(\tR\vactorUserId\x12>\n" +
+	"\fpost_created\x18\n" +
+	" \x01(\v2\x19.proto.events.PostCreatedH\x00R\vpostCreated\x12>\n" +
+	"\fpost_updated\x18\v \x01(\v2\x19.proto.events.PostUpdatedH\x00R\vpostUpdated\x12>\n" +
+	"\fpost_deleted\x18\f \x01(\v2\x19.proto.events.PostDeletedH\x00R\vpostDeleted\x12G\n" +
+	"\x0fcomment_created\x18\r \x01(\v2\x1c.proto.events.CommentCreatedH\x00R\x0ecommentCreated\x12G\n" +
+	"\x0fcomment_updated\x18\x0e \x01(\v2\x1c.proto.events.CommentUpdatedH\x00R\x0ecommentUpdated\x12G\n" +
+	"\x0fcomment_deleted\x18\x0f \x01(\v2\x1c.proto.events.CommentDeletedH\x00R\x0ecommentDeleted\x12>\n" +
+	"\freaction_set\x18\x10 \x01(\v2\x19.proto.events.ReactionSetH\x00R\vreactionSet\x12J\n" +
+	"\x10reaction_removed\x18\x11 \x01(\v2\x1d.proto.events.ReactionRemovedH\x00R\x0freactionRemoved\x12>\n" +
+	"\fpost_trashed\x18\x12 \x01(\v2\x19.proto.events.PostTrashedH\x00R\vpostTrashed\x12A\n" +
+	"\rpost_restored\x18\x13 \x01(\v2\x1a.proto.events.PostRestoredH\x00R\fpostRestored\x12D\n" +
+	"\x0epost_published\x18\x14 \x01(\v2\x1b.proto.events.PostPublishedH\x00R\rpostPublishedB\t\n" +
+	"\apayload\"u\n" +
+	"\vPostCreated\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x01 \x01(\tR\tcreatorId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x03 \x01(\bR\tisPrivate\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\"V\n" +
+	"\vPostUpdated\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x02 \x01(\bR\tisPrivate\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"D\n" +
+	"\vPostTrashed\x125\n" +
+	"\bpurge_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\apurgeAt\"\x0f\n" +
+	"\rPostPublished\"\x0e\n" +
+	"\fPostRestored\"\r\n" +
+	"\vPostDeleted\"[\n" +
+	"\x0eCommentCreated\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12*\n" +
+	"\x11parent_comment_id\x18\x02 \x01(\tR\x0fparentCommentId\"/\n" +
+	"\x0eCommentUpdated\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\"T\n" +
+	"\x0eCommentDeleted\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x01 \x01(\tR\tcommentId\x12#\n" +
+	"\rdeleted_count\x18\x02 \x01(\x05R\fdeletedCount\"h\n" +
+	"\vReactionSet\x12#\n" +
+	"\rreaction_type\x18\x01 \x01(\tR\freactionType\x124\n" +
+	"\x16previous_reaction_type\x18\x02 \x01(\tR\x14previousReactionType\"6\n" +
+	"\x0fReactionRemoved\x12#\n" +
+	"\rreaction_type\x18\x01 \x01(\tR\freactionType\"\xc5\x01\n" +
+	"\n" +
+	"PostViewed\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12;\n" +
+	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x17\n" +
+	"\apost_id\x18\x03 \x01(\tR\x06postId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x05 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ipB\n" +
+	"Z\b./eventsb\x06proto3"
+
+var (
+	file_events_proto_rawDescOnce sync.Once
+	file_events_proto_rawDescData []byte
+)
+
+func file_events_proto_rawDescGZIP() []byte {
+	file_events_proto_rawDescOnce.Do(func() {
+		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)))
+	})
+	return file_events_proto_rawDescData
+}
+
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_events_proto_goTypes = []any{
+	(*PostEvent)(nil),             // 0: proto.events.PostEvent
+	(*PostCreated)(nil),           // 1: proto.events.PostCreated
+	(*PostUpdated)(nil),           // 2: proto.events.PostUpdated
+	(*PostTrashed)(nil),           // 3: proto.events.PostTrashed
+	(*PostPublished)(nil),         // 4: proto.events.PostPublished
+	(*PostRestored)(nil),          // 5: proto.events.PostRestored
+	(*PostDeleted)(nil),           // 6: proto.events.PostDeleted
+	(*CommentCreated)(nil),        // 7: proto.events.CommentCreated
+	(*CommentUpdated)(nil),        // 8: proto.events.CommentUpdated
+	(*CommentDeleted)(nil),        // 9: proto.events.CommentDeleted
+	(*ReactionSet)(nil),           // 10: proto.events.ReactionSet
+	(*ReactionRemoved)(nil),       // 11: proto.events.ReactionRemoved
+	(*PostViewed)(nil),            // 12: proto.events.PostViewed
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+}
+var file_events_proto_depIdxs = []int32{
+	13, // 0: proto.events.PostEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 1: proto.events.PostEvent.post_created:type_name -> proto.events.PostCreated
+	2,  // 2: proto.events.PostEvent.post_updated:type_name -> proto.events.PostUpdated
+	6,  // 3: proto.events.PostEvent.post_deleted:type_name -> proto.events.PostDeleted
+	7,  // 4: proto.events.PostEvent.comment_created:type_name -> proto.events.CommentCreated
+	8,  // 5: proto.events.PostEvent.comment_updated:type_name -> proto.events.CommentUpdated
+	9,  // 6: proto.events.PostEvent.comment_deleted:type_name -> proto.events.CommentDeleted
+	10, // 7: proto.events.PostEvent.reaction_set:type_name -> proto.events.ReactionSet
+	11, // 8: proto.events.PostEvent.reaction_removed:type_name -> proto.events.ReactionRemoved
+	3,  // 9: proto.events.PostEvent.post_trashed:type_name -> proto.events.PostTrashed
+	5,  // 10: proto.events.PostEvent.post_restored:type_name -> proto.events.PostRestored
+	4,  // 11: proto.events.PostEvent.post_published:type_name -> proto.events.PostPublished
+	13, // 12: proto.events.PostTrashed.purge_at:type_name -> google.protobuf.Timestamp
+	13, // 13: proto.events.PostViewed.occurred_at:type_name -> google.protobuf.Timestamp
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_events_proto_init() }
+func file_events_proto_init() {
+	if File_events_proto != nil {
+		return
+	}
+	file_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*PostEvent_PostCreated)(nil),
+		(*PostEvent_PostUpdated)(nil),
+		(*PostEvent_PostDeleted)(nil),
+		(*PostEvent_CommentCreated)(nil),
+		(*PostEvent_CommentUpdated)(nil),
+		(*PostEvent_CommentDeleted)(nil),
+		(*PostEvent_ReactionSet)(nil),
+		(*PostEvent_ReactionRemoved)(nil),
+		(*PostEvent_PostTrashed)(nil),
+		(*PostEvent_PostRestored)(nil),
+		(*PostEvent_PostPublished)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_proto_goTypes,
+		DependencyIndexes: file_events_proto_depIdxs,
+		MessageInfos:      file_events_proto_msgTypes,
+	}.Build()
+	File_events_proto = out.File
+	file_events_proto_goTypes = nil
+	file_events_proto_depIdxs = nil
+}
@@ -0,0 +1,858 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: statistics.proto
+
+package statistics
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Metric int32
+
+const (
+	Metric_METRIC_UNSPECIFIED Metric = 0
+	Metric_METRIC_VIEWS       Metric = 1
+	Metric_METRIC_LIKES       Metric = 2
+	Metric_METRIC_COMMENTS    Metric = 3
+)
+
+// Enum value maps for Metric.
+var (
+	Metric_name = map[int32]string{
+		0: "METRIC_UNSPECIFIED",
+		1: "METRIC_VIEWS",
+		2: "METRIC_LIKES",
+		3: "METRIC_COMMENTS",
+	}
+	Metric_value = map[string]int32{
+		"METRIC_UNSPECIFIED": 0,
+		"METRIC_VIEWS":       1,
+		"METRIC_LIKES":       2,
+		"METRIC_COMMENTS":    3,
+	}
+)
+
+func (x Metric) Enum() *Metric {
+	p := new(Metric)
+	*p = x
+	return p
+}
+
+func (x Metric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Metric) Descriptor() protoreflect.EnumDescriptor {
+	return file_statistics_proto_enumTypes[0].Descriptor()
+}
+
+func (Metric) Type() protoreflect.EnumType {
+	return &file_statistics_proto_enumTypes[0]
+}
+
+func (x Metric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Metric.Descriptor instead.
+func (Metric) EnumDescriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{0}
+}
+
+type PostStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Views         int64                  `protobuf:"varint,2,opt,name=views,proto3" json:"views,omitempty"`
+	Likes         int64                  `protobuf:"varint,3,opt,name=likes,proto3" json:"likes,omitempty"`
+	Comments      int64                  `protobuf:"varint,4,opt,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostStats) Reset() {
+	*x = PostStats{}
+	mi := &file_statistics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostStats) ProtoMessage() {}
+
+func (x *PostStats) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostStats.ProtoReflect.Descriptor instead.
+func (*PostStats) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PostStats) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *PostStats) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *PostStats) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *PostStats) GetComments() int64 {
+	if x != nil {
+		return x.Comments
+	}
+	return 0
+}
+
+type GetPostStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostStatsRequest) Reset() {
+	*x = GetPostStatsRequest{}
+	mi := &file_statistics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostStatsRequest) ProtoMessage() {}
+
+func (x *GetPostStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetPostStatsRequest) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetPostStatsRequest) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+type GetPostStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *PostStats             `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostStatsResponse) Reset() {
+	*x = GetPostStatsResponse{}
+	mi := &file_statistics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostStatsResponse) ProtoMessage() {}
+
+func (x *GetPostStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetPostStatsResponse) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPostStatsResponse) GetStats() *PostStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetPostStatsBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostIds       []string               `protobuf:"bytes,1,rep,name=post_ids,json=postIds,proto3" json:"post_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostStatsBatchRequest) Reset() {
+	*x = GetPostStatsBatchRequest{}
+	mi := &file_statistics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostStatsBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostStatsBatchRequest) ProtoMessage() {}
+
+func (x *GetPostStatsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostStatsBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetPostStatsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPostStatsBatchRequest) GetPostIds() []string {
+	if x != nil {
+		return x.PostIds
+	}
+	return nil
+}
+
+type GetPostStatsBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order of post_ids; posts without events have zero counters.
+	Stats         []*PostStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostStatsBatchResponse) Reset() {
+	*x = GetPostStatsBatchResponse{}
+	mi := &file_statistics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostStatsBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostStatsBatchResponse) ProtoMessage() {}
+
+func (x *GetPostStatsBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostStatsBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetPostStatsBatchResponse) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPostStatsBatchResponse) GetStats() []*PostStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetTopPostsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Metric Metric                 `protobuf:"varint,1,opt,name=metric,proto3,enum=proto.statistics.Metric" json:"metric,omitempty"`
+	// Only counts events this recent. Counts all events if unset.
+	Window        *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Limit         int32                `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopPostsRequest) Reset() {
+	*x = GetTopPostsRequest{}
+	mi := &file_statistics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopPostsRequest) ProtoMessage() {}
+
+func (x *GetTopPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopPostsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopPostsRequest) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTopPostsRequest) GetMetric() Metric {
+	if x != nil {
+		return x.Metric
+	}
+	return Metric_METRIC_UNSPECIFIED
+}
+
+func (x *GetTopPostsRequest) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *GetTopPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopPost struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PostId string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	// Value of the requested metric within the window.
+	Count         int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopPost) Reset() {
+	*x = TopPost{}
+	mi := &file_statistics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopPost) ProtoMessage() {}
+
+func (x *TopPost) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopPost.ProtoReflect.Descriptor instead.
+func (*TopPost) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TopPost) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *TopPost) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetTopPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by count, highest first.
+	Posts         []*TopPost `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopPostsResponse) Reset() {
+	*x = GetTopPostsResponse{}
+	mi := &file_statistics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopPostsResponse) ProtoMessage() {}
+
+func (x *GetTopPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopPostsResponse.ProtoReflect.Descriptor instead.
+func (*GetTopPostsResponse) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTopPostsResponse) GetPosts() []*TopPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+type GetTrendingPostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingPostsRequest) Reset() {
+	*x = GetTrendingPostsRequest{}
+	mi := &file_statistics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingPostsRequest) ProtoMessage() {}
+
+func (x *GetTrendingPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingPostsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendingPostsRequest) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTrendingPostsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TrendingPost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostId        string                 `protobuf:"bytes,1,opt,name=post_id,json=postId,proto3" json:"post_id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingPost) Reset() {
+	*x = TrendingPost{}
+	mi := &file_statistics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingPost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingPost) ProtoMessage() {}
+
+func (x *TrendingPost) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingPost.ProtoReflect.Descriptor instead.
+func (*TrendingPost) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TrendingPost) GetPostId() string {
+	if x != nil {
+		return x.PostId
+	}
+	return ""
+}
+
+func (x *TrendingPost) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetTrendingPostsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ordered by score, highest first. Includes private posts; callers
+	// filter by visibility.
+	Posts []*TrendingPost `protobuf:"bytes,1,rep,name=posts,proto3" json:"posts,omitempty"`
+	// When the ranking was last recomputed.
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingPostsResponse) Reset() {
+	*x = GetTrendingPostsResponse{}
+	mi := &file_statistics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingPostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingPostsResponse) ProtoMessage() {}
+
+func (x *GetTrendingPostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingPostsResponse.ProtoReflect.Descriptor instead.
+func (*GetTrendingPostsResponse) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTrendingPostsResponse) GetPosts() []*TrendingPost {
+	if x != nil {
+		return x.Posts
+	}
+	return nil
+}
+
+func (x *GetTrendingPostsResponse) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+type PublishEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Serialized proto.events.PostEvent messages, as posts publishes them to
+	// Kafka. Events of a post must be sent in order.
+	PostEvents [][]byte `protobuf:"bytes,1,rep,name=post_events,json=postEvents,proto3" json:"post_events,omitempty"`
+	// Serialized proto.events.PostViewed messages.
+	PostViews     [][]byte `protobuf:"bytes,2,rep,name=post_views,json=postViews,proto3" json:"post_views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishEventsRequest) Reset() {
+	*x = PublishEventsRequest{}
+	mi := &file_statistics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishEventsRequest) ProtoMessage() {}
+
+func (x *PublishEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishEventsRequest.ProtoReflect.Descriptor instead.
+func (*PublishEventsRequest) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PublishEventsRequest) GetPostEvents() [][]byte {
+	if x != nil {
+		return x.PostEvents
+	}
+	return nil
+}
+
+func (x *PublishEventsRequest) GetPostViews() [][]byte {
+	if x != nil {
+		return x.PostViews
+	}
+	return nil
+}
+
+type PublishEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishEventsResponse) Reset() {
+	*x = PublishEventsResponse{}
+	mi := &file_statistics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishEventsResponse) ProtoMessage() {}
+
+func (x *PublishEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_statistics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishEventsResponse.ProtoReflect.Descriptor instead.
+func (*PublishEventsResponse) Descriptor() ([]byte, []int) {
+	return file_statistics_proto_rawDescGZIP(), []int{12}
+}
+
+var File_statistics_proto protoreflect.FileDescriptor
+
+const file_statistics_proto_rawDesc = "" +
+	"\n" +
+	"\x10statistics.proto\x12\x10proto.statistics\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"l\n" +
+	"\tPostStats\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05views\x18\x02 \x01(\x03R\x05views\x12\x14\n" +
+	"\x05likes\x18\x03 \x01(\x03R\x05likes\x12\x1a\n" +
+	"\bcomments\x18\x04 \x01(\x03R\bcomments\".\n" +
+	"\x13GetPostStatsRequest\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\"I\n" +
+	"\x14GetPostStatsResponse\x121\n" +
+	"\x05stats\x18\x01 \x01(\v2\x1b.proto.statistics.PostStatsR\x05stats\"5\n" +
+	"\x18GetPostStatsBatchRequest\x12\x19\n" +
+	"\bpost_ids\x18\x01 \x03(\tR\apostIds\"N\n" +
+	"\x19GetPostStatsBatchResponse\x121\n" +
+	"\x05stats\x18\x01 \x03(\v2\x1b.proto.statistics.PostStatsR\x05stats\"\x8f\x01\n" +
+	"\x12GetTopPostsRequest\x120\n" +
+	"\x06metric\x18\x01 \x01(\x0e2\x18.proto.statistics.MetricR\x06metric\x121\n" +
+	"\x06window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"8\n" +
+	"\aTopPost\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"F\n" +
+	"\x13GetTopPostsResponse\x12/\n" +
+	"\x05posts\x18\x01 \x03(\v2\x19.proto.statistics.TopPostR\x05posts\"/\n" +
+	"\x17GetTrendingPostsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"=\n" +
+	"\fTrendingPost\x12\x17\n" +
+	"\apost_id\x18\x01 \x01(\tR\x06postId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\x8d\x01\n" +
+	"\x18GetTrendingPostsResponse\x124\n" +
+	"\x05posts\x18\x01 \x03(\v2\x1e.proto.statistics.TrendingPostR\x05posts\x12;\n" +
+	"\vcomputed_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\"V\n" +
+	"\x14PublishEventsRequest\x12\x1f\n" +
+	"\vpost_events\x18\x01 \x03(\fR\n" +
+	"postEvents\x12\x1d\n" +
+	"\n" +
+	"post_views\x18\x02 \x03(\fR\tpostViews\"\x17\n" +
+	"\x15PublishEventsResponse*Y\n" +
+	"\x06Metric\x12\x16\n" +
+	"\x12METRIC_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fMETRIC_VIEWS\x10\x01\x12\x10\n" +
+	"\fMETRIC_LIKES\x10\x02\x12\x13\n" +
+	"\x0fMETRIC_COMMENTS\x10\x032\x89\x04\n" +
+	"\x11StatisticsService\x12]\n" +
+	"\fGetPostStats\x12%.proto.statistics.GetPostStatsRequest\x1a&.proto.statistics.GetPostStatsResponse\x12l\n" +
+	"\x11GetPostStatsBatch\x12*.proto.statistics.GetPostStatsBatchRequest\x1a+.proto.statistics.GetPostStatsBatchResponse\x12Z\n" +
+	"\vGetTopPosts\x12$.proto.statistics.GetTopPostsRequest\x1a%.proto.statistics.GetTopPostsResponse\x12i\n" +
+	"\x10GetTrendingPosts\x12).proto.statistics.GetTrendingPostsRequest\x1a*.proto.statistics.GetTrendingPostsResponse\x12`\n" +
+	"\rPublishEvents\x12&.proto.statistics.PublishEventsRequest\x1a'.proto.statistics.PublishEventsResponseB\x0eZ\f./statisticsb\x06proto3"
+
+var (
+	file_statistics_proto_rawDescOnce sync.Once
+	file_statistics_proto_rawDescData []byte
+)
+
+func file_statistics_proto_rawDescGZIP() []byte {
+	file_statistics_proto_rawDescOnce.Do(func() {
+		file_statistics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_statistics_proto_rawDesc), len(file_statistics_proto_rawDesc)))
+	})
+	return file_statistics_proto_rawDescData
+}
+
+var file_statistics_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_statistics_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_statistics_proto_goTypes = []any{
+	(Metric)(0),                       // 0: proto.statistics.Metric
+	(*PostStats)(nil),                 // 1: proto.statistics.PostStats
+	(*GetPostStatsRequest)(nil),       // 2: proto.statistics.GetPostStatsRequest
+	(*GetPostStatsResponse)(nil),      // 3: proto.statistics.GetPostStatsResponse
+	(*GetPostStatsBatchRequest)(nil),  // 4: proto.statistics.GetPostStatsBatchRequest
+	(*GetPostStatsBatchResponse)(nil), // 5: proto.statistics.GetPostStatsBatchResponse
+	(*GetTopPostsRequest)(nil),        // 6: proto.statistics.GetTopPostsRequest
+	(*TopPost)(nil),                   // 7: proto.statistics.TopPost
+	(*GetTopPostsResponse)(nil),       // 8: proto.statistics.GetTopPostsResponse
+	(*GetTrendingPostsRequest)(nil),   // 9: proto.statistics.GetTrendingPostsRequest
+	(*TrendingPost)(nil),              // 10: proto.statistics.TrendingPost
+	(*GetTrendingPostsResponse)(nil),  // 11: proto.statistics.GetTrendingPostsResponse
+	(*PublishEventsRequest)(nil),      // 12: proto.statistics.PublishEventsRequest
+	(*PublishEventsResponse)(nil),     // 13: proto.statistics.PublishEventsResponse
+	(*durationpb.Duration)(nil),       // 14: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),     // 15: google.protobuf.Timestamp
+}
+var file_statistics_proto_depIdxs = []int32{
+	1,  // 0: proto.statistics.GetPostStatsResponse.stats:type_name -> proto.statistics.PostStats
+	1,  // 1: proto.statistics.GetPostStatsBatchResponse.stats:type_name -> proto.statistics.PostStats
+	0,  // 2: proto.statistics.GetTopPostsRequest.metric:type_name -> proto.statistics.Metric
+	14, // 3: proto.statistics.GetTopPostsRequest.window:type_name -> google.protobuf.Duration
+	7,  // 4: proto.statistics.GetTopPostsResponse.posts:type_name -> proto.statistics.TopPost
+	10, // 5: proto.statistics.GetTrendingPostsResponse.posts:type_name -> proto.statistics.TrendingPost
+	15, // 6: proto.statistics.GetTrendingPostsResponse.computed_at:type_name -> google.protobuf.Timestamp
+	2,  // 7: proto.statistics.StatisticsService.GetPostStats:input_type -> proto.statistics.GetPostStatsRequest
+	4,  // 8: proto.statistics.StatisticsService.GetPostStatsBatch:input_type -> proto.statistics.GetPostStatsBatchRequest
+	6,  // 9: proto.statistics.StatisticsService.GetTopPosts:input_type -> proto.statistics.GetTopPostsRequest
+	9,  // 10: proto.statistics.StatisticsService.GetTrendingPosts:input_type -> proto.statistics.GetTrendingPostsRequest
+	12, // 11: proto.statistics.StatisticsService.PublishEvents:input_type -> proto.statistics.PublishEventsRequest
+	3,  // 12: proto.statistics.StatisticsService.GetPostStats:output_type -> proto.statistics.GetPostStatsResponse
+	5,  // 13: proto.statistics.StatisticsService.GetPostStatsBatch:output_type -> proto.statistics.GetPostStatsBatchResponse
+	8,  // 14: proto.statistics.StatisticsService.GetTopPosts:output_type -> proto.statistics.GetTopPostsResponse
+	11, // 15: proto.statistics.StatisticsService.GetTrendingPosts:output_type -> proto.statistics.GetTrendingPostsResponse
+	13, // 16: proto.statistics.StatisticsService.PublishEvents:output_type -> proto.statistics.PublishEventsResponse
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_statistics_proto_init() }
+func file_statistics_proto_init() {
+	if File_statistics_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_statistics_proto_rawDesc), len(file_statistics_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_statistics_proto_goTypes,
+		DependencyIndexes: file_statistics_proto_depIdxs,
+		EnumInfos:         file_statistics_proto_enumTypes,
+		MessageInfos:      file_statistics_proto_msgTypes,
+	}.Build()
+	File_statistics_proto = out.File
+	file_statistics_proto_goTypes = nil
+	file_statistics_proto_depIdxs = nil
+}
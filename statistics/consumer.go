@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Consumer delivers events from a broker to handle until ctx is cancelled.
+// An event is redelivered until handle succeeds, so handle must be
+// idempotent.
+type Consumer interface {
+	Consume(ctx context.Context, handle func(context.Context, Event) error) error
+}
+
+// retryInterval is how long a consumer waits before redelivering an event
+// that failed to be handled.
+const retryInterval = time.Second
+
+func handleWithRetries(ctx context.Context, event Event, handle func(context.Context, Event) error) error {
+	for {
+		err := handle(ctx, event)
+		if err == nil {
+			return nil
+		}
+		fmt.Printf("Failed to handle event %s: %v\n", event.EventId, err)
+		select {
+		case <-time.After(retryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,38 @@
+DROP TABLE IF EXISTS events, post_stats, post_activity CASCADE;
+
+-- Every event applied to the counters. event_id makes redelivered events
+-- no-ops, and the rows are kept for windowed queries.
+CREATE TABLE events (
+    event_id VARCHAR(64) PRIMARY KEY,
+    metric VARCHAR(16) NOT NULL,
+    post_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    delta INTEGER NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    -- Only set for views.
+    user_agent TEXT,
+    ip VARCHAR(45)
+);
+
+CREATE INDEX events_metric_occurred_at_idx ON events (metric, occurred_at);
+
+-- All-time counters, updated together with events.
+CREATE TABLE post_stats (
+    post_id VARCHAR(36) PRIMARY KEY,
+    views BIGINT NOT NULL DEFAULT 0,
+    likes BIGINT NOT NULL DEFAULT 0,
+    comments BIGINT NOT NULL DEFAULT 0
+);
+
+-- Hourly counters, updated together with events. Trending is ranked from
+-- the recent buckets only.
+CREATE TABLE post_activity (
+    post_id VARCHAR(36) NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    likes BIGINT NOT NULL DEFAULT 0,
+    comments BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, bucket_start)
+);
+
+CREATE INDEX post_activity_bucket_start_idx ON post_activity (bucket_start);
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"msg.i3cheese.ru/proto/events"
+	"msg.i3cheese.ru/proto/statistics"
+)
+
+// Metric is a counter kept for every post.
+type Metric string
+
+const (
+	MetricViews    Metric = "views"
+	MetricLikes    Metric = "likes"
+	MetricComments Metric = "comments"
+)
+
+func (m Metric) valid() bool {
+	return m == MetricViews || m == MetricLikes || m == MetricComments
+}
+
+func metricFromProto(metric statistics.Metric) (Metric, bool) {
+	switch metric {
+	case statistics.Metric_METRIC_VIEWS:
+		return MetricViews, true
+	case statistics.Metric_METRIC_LIKES:
+		return MetricLikes, true
+	case statistics.Metric_METRIC_COMMENTS:
+		return MetricComments, true
+	default:
+		return "", false
+	}
+}
+
+// Event changes one counter of a post by Delta, e.g. -1 for a removed like.
+// EventId is unique per event so redelivered events are counted once.
+// UserAgent and Ip are only known for views.
+type Event struct {
+	EventId    string
+	Metric     Metric
+	PostId     string
+	UserId     string
+	Delta      int64
+	OccurredAt time.Time
+	UserAgent  string
+	Ip         string
+}
+
+type PostStats struct {
+	PostId   string
+	Views    int64
+	Likes    int64
+	Comments int64
+}
+
+func (s PostStats) toProto() *statistics.PostStats {
+	return &statistics.PostStats{
+		PostId:   s.PostId,
+		Views:    s.Views,
+		Likes:    s.Likes,
+		Comments: s.Comments,
+	}
+}
+
+type TopPost struct {
+	PostId string
+	Count  int64
+}
+
+// decodePostEvent decodes a serialized proto.events.PostEvent, as posts
+// publishes it, into the counter changes it causes.
+func decodePostEvent(value []byte) ([]Event, error) {
+	var postEvent events.PostEvent
+	if err := proto.Unmarshal(value, &postEvent); err != nil {
+		return nil, err
+	}
+	return eventsFromPostEvent(&postEvent), nil
+}
+
+// decodePostViewed decodes a serialized proto.events.PostViewed, as the
+// gateway publishes it.
+func decodePostViewed(value []byte) ([]Event, error) {
+	var view events.PostViewed
+	if err := proto.Unmarshal(value, &view); err != nil {
+		return nil, err
+	}
+	return []Event{{
+		EventId:    view.EventId,
+		Metric:     MetricViews,
+		PostId:     view.PostId,
+		UserId:     view.UserId,
+		Delta:      1,
+		OccurredAt: view.OccurredAt.AsTime(),
+		UserAgent:  view.UserAgent,
+		Ip:         view.Ip,
+	}}, nil
+}
+
+// eventsFromPostEvent returns the counter changes a post event causes. Most
+// events, such as edits, change no counters.
+func eventsFromPostEvent(postEvent *events.PostEvent) []Event {
+	event := Event{
+		EventId:    postEvent.EventId,
+		PostId:     postEvent.PostId,
+		UserId:     postEvent.ActorUserId,
+		OccurredAt: postEvent.OccurredAt.AsTime(),
+	}
+	switch payload := postEvent.Payload.(type) {
+	case *events.PostEvent_ReactionSet:
+		// Replacing a reaction does not change the number of reactions.
+		if payload.ReactionSet.PreviousReactionType != "" {
+			return nil
+		}
+		event.Metric, event.Delta = MetricLikes, 1
+	case *events.PostEvent_ReactionRemoved:
+		event.Metric, event.Delta = MetricLikes, -1
+	case *events.PostEvent_CommentCreated:
+		event.Metric, event.Delta = MetricComments, 1
+	case *events.PostEvent_CommentDeleted:
+		event.Metric, event.Delta = MetricComments, -int64(payload.CommentDeleted.DeletedCount)
+	default:
+		return nil
+	}
+	return []Event{event}
+}
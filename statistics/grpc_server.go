@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"msg.i3cheese.ru/proto/statistics"
+)
+
+const (
+	defaultTopPostsLimit = 10
+	maxTopPostsLimit     = 100
+	maxBatchSize         = 1000
+	// Callers filter trending posts by visibility, so they may ask for more
+	// than they show; the limit is the size of the cached ranking.
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = trendingCacheSize
+	maxPublishedEvents   = 1000
+)
+
+type StatisticsServiceServer struct {
+	statistics.UnimplementedStatisticsServiceServer
+	App *App
+}
+
+func (s *StatisticsServiceServer) GetPostStats(ctx context.Context, req *statistics.GetPostStatsRequest) (*statistics.GetPostStatsResponse, error) {
+	if req.PostId == "" {
+		return nil, status.Error(codes.InvalidArgument, "post_id is required")
+	}
+	stats, err := s.App.Storage.GetPostStats(ctx, []string{req.PostId})
+	if err != nil {
+		fmt.Printf("Failed to fetch post stats: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch post stats: %v", err)
+	}
+
+	result := stats[req.PostId]
+	result.PostId = req.PostId
+	return &statistics.GetPostStatsResponse{Stats: result.toProto()}, nil
+}
+
+func (s *StatisticsServiceServer) GetPostStatsBatch(ctx context.Context, req *statistics.GetPostStatsBatchRequest) (*statistics.GetPostStatsBatchResponse, error) {
+	if len(req.PostIds) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d post_ids are allowed", maxBatchSize)
+	}
+	stats, err := s.App.Storage.GetPostStats(ctx, req.PostIds)
+	if err != nil {
+		fmt.Printf("Failed to fetch post stats: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch post stats: %v", err)
+	}
+
+	result := make([]*statistics.PostStats, 0, len(req.PostIds))
+	for _, postId := range req.PostIds {
+		postStats := stats[postId]
+		postStats.PostId = postId
+		result = append(result, postStats.toProto())
+	}
+	return &statistics.GetPostStatsBatchResponse{Stats: result}, nil
+}
+
+func (s *StatisticsServiceServer) GetTopPosts(ctx context.Context, req *statistics.GetTopPostsRequest) (*statistics.GetTopPostsResponse, error) {
+	metric, ok := metricFromProto(req.Metric)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown metric %v", req.Metric)
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultTopPostsLimit
+	}
+	if limit > maxTopPostsLimit {
+		limit = maxTopPostsLimit
+	}
+	var since time.Time
+	if req.Window != nil {
+		if err := req.Window.CheckValid(); err != nil || req.Window.AsDuration() <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "window must be positive")
+		}
+		since = time.Now().Add(-req.Window.AsDuration())
+	}
+
+	top, err := s.App.Storage.TopPosts(ctx, metric, since, limit)
+	if err != nil {
+		fmt.Printf("Failed to fetch top posts: %v\n", err)
+		return nil, status.Errorf(codes.Internal, "failed to fetch top posts: %v", err)
+	}
+
+	result := make([]*statistics.TopPost, 0, len(top))
+	for _, t := range top {
+		result = append(result, &statistics.TopPost{PostId: t.PostId, Count: t.Count})
+	}
+	return &statistics.GetTopPostsResponse{Posts: result}, nil
+}
+
+func (s *StatisticsServiceServer) GetTrendingPosts(ctx context.Context, req *statistics.GetTrendingPostsRequest) (*statistics.GetTrendingPostsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+
+	posts, computedAt := s.App.Trending.Get(limit)
+	if computedAt.IsZero() {
+		return nil, status.Error(codes.Unavailable, "trending posts are not computed yet")
+	}
+
+	result := make([]*statistics.TrendingPost, 0, len(posts))
+	for _, p := range posts {
+		result = append(result, &statistics.TrendingPost{PostId: p.PostId, Score: p.Score})
+	}
+	return &statistics.GetTrendingPostsResponse{Posts: result, ComputedAt: timestamppb.New(computedAt)}, nil
+}
+
+func (s *StatisticsServiceServer) PublishEvents(ctx context.Context, req *statistics.PublishEventsRequest) (*statistics.PublishEventsResponse, error) {
+	if s.App.Consumer != nil {
+		return nil, status.Error(codes.FailedPrecondition, "events are consumed from the broker")
+	}
+	if len(req.PostEvents)+len(req.PostViews) > maxPublishedEvents {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d events are allowed", maxPublishedEvents)
+	}
+
+	// As with Kafka, redelivering will not fix an event that can not be
+	// decoded, so it is skipped instead of failing the call.
+	var decoded []Event
+	for _, value := range req.PostEvents {
+		postEvents, err := decodePostEvent(value)
+		if err != nil {
+			fmt.Printf("Skipping undecodable post event: %v\n", err)
+		}
+		decoded = append(decoded, postEvents...)
+	}
+	for _, value := range req.PostViews {
+		views, err := decodePostViewed(value)
+		if err != nil {
+			fmt.Printf("Skipping undecodable post view: %v\n", err)
+		}
+		decoded = append(decoded, views...)
+	}
+
+	// Events are recorded in order and redelivered ones are ignored, so a
+	// caller retrying a failed call counts every event once.
+	for _, event := range decoded {
+		if err := s.App.handleEvent(ctx, event); err != nil {
+			fmt.Printf("Failed to record event %s: %v\n", event.EventId, err)
+			return nil, status.Errorf(codes.Internal, "failed to record event: %v", err)
+		}
+	}
+	return &statistics.PublishEventsResponse{}, nil
+}
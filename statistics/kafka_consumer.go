@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConsumer reads post events and views from Kafka as part of a consumer
+// group. Offsets are committed only after every event of a message is
+// handled.
+type KafkaConsumer struct {
+	reader          *kafka.Reader
+	postEventsTopic string
+	postViewsTopic  string
+}
+
+func NewKafkaConsumer(brokers []string, groupId string, postEventsTopic string, postViewsTopic string) *KafkaConsumer {
+	return &KafkaConsumer{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     brokers,
+			GroupTopics: []string{postEventsTopic, postViewsTopic},
+			GroupID:     groupId,
+		}),
+		postEventsTopic: postEventsTopic,
+		postViewsTopic:  postViewsTopic,
+	}
+}
+
+func (c *KafkaConsumer) Consume(ctx context.Context, handle func(context.Context, Event) error) error {
+	defer c.reader.Close()
+	for {
+		message, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to fetch message: %w", err)
+		}
+
+		decoded, err := c.decode(message)
+		if err != nil {
+			// Redelivering will not fix a message that can not be decoded.
+			fmt.Printf("Skipping undecodable message in %s at offset %d: %v\n", message.Topic, message.Offset, err)
+		}
+		for _, event := range decoded {
+			if err := handleWithRetries(ctx, event, handle); err != nil {
+				return err
+			}
+		}
+
+		if err := c.reader.CommitMessages(ctx, message); err != nil {
+			return fmt.Errorf("failed to commit message: %w", err)
+		}
+	}
+}
+
+func (c *KafkaConsumer) decode(message kafka.Message) ([]Event, error) {
+	switch message.Topic {
+	case c.postEventsTopic:
+		return decodePostEvent(message.Value)
+	case c.postViewsTopic:
+		return decodePostViewed(message.Value)
+	default:
+		return nil, fmt.Errorf("unexpected topic %s", message.Topic)
+	}
+}
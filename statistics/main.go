@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"msg.i3cheese.ru/proto/statistics"
+)
+
+// trendingCacheSize is how many top posts the trending ranking keeps.
+const trendingCacheSize = 1000
+
+type App struct {
+	Storage Storage
+	// Consumer is nil when events are published directly through
+	// PublishEvents instead of a broker.
+	Consumer Consumer
+	Trending *TrendingCache
+}
+
+// handleEvent applies an event to the counters. Malformed events are dropped
+// rather than redelivered forever.
+func (app *App) handleEvent(ctx context.Context, event Event) error {
+	if event.EventId == "" || event.PostId == "" || !event.Metric.valid() {
+		fmt.Printf("Dropping malformed event %+v\n", event)
+		return nil
+	}
+	return app.Storage.Record(ctx, event)
+}
+
+func connectWithRetries(ctx context.Context, dsn string, maxRetries int) (*pgxpool.Pool, error) {
+	var pool *pgxpool.Pool
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		pool, err = pgxpool.New(ctx, dsn)
+		if err == nil {
+			err = pool.Ping(ctx)
+		}
+		if err == nil {
+			return pool, nil
+		}
+		if pool != nil {
+			pool.Close()
+		}
+		fmt.Fprintf(os.Stderr, "Attempt %d: Unable to connect to database: %v\n", i+1, err)
+		time.Sleep(2 * time.Second) // Add a delay between retries
+	}
+	return nil, err
+}
+
+func newStorage() Storage {
+	switch os.Getenv("STORAGE") {
+	case "", "postgres":
+		pool, err := connectWithRetries(context.Background(), os.Getenv("DATABASE_URL"), 10)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to connect to database after retries: %v\n", err)
+			os.Exit(1)
+		}
+		return &PostgresStorage{DB: pool}
+	case "memory":
+		return NewMemoryStorage()
+	default:
+		fmt.Printf("Unknown STORAGE %q, expected postgres or memory\n", os.Getenv("STORAGE"))
+		os.Exit(1)
+		return nil
+	}
+}
+
+func newConsumer() Consumer {
+	switch os.Getenv("BROKER") {
+	case "", "direct":
+		return nil
+	case "kafka":
+		brokers := os.Getenv("KAFKA_BROKERS")
+		if brokers == "" {
+			fmt.Println("KAFKA_BROKERS is required")
+			os.Exit(1)
+		}
+		postEventsTopic := os.Getenv("POST_EVENTS_TOPIC")
+		if postEventsTopic == "" {
+			postEventsTopic = "post-events"
+		}
+		postViewsTopic := os.Getenv("POST_VIEWS_TOPIC")
+		if postViewsTopic == "" {
+			postViewsTopic = "post-views"
+		}
+		return NewKafkaConsumer(strings.Split(brokers, ","), "statistics", postEventsTopic, postViewsTopic)
+	default:
+		fmt.Printf("Unknown BROKER %q, expected direct or kafka\n", os.Getenv("BROKER"))
+		os.Exit(1)
+		return nil
+	}
+}
+
+// durationFromEnv parses the duration in the given variable, falling back to
+// the default when it is not set.
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		fmt.Printf("%s must be a positive duration such as 24h, got %q\n", name, value)
+		os.Exit(1)
+	}
+	return duration
+}
+
+func newTrendingConfig() TrendingConfig {
+	return TrendingConfig{
+		Window:          durationFromEnv("TRENDING_WINDOW", 24*time.Hour),
+		HalfLife:        durationFromEnv("TRENDING_HALF_LIFE", 6*time.Hour),
+		RefreshInterval: durationFromEnv("TRENDING_REFRESH_INTERVAL", time.Minute),
+		Size:            trendingCacheSize,
+	}
+}
+
+func main() {
+	storage := newStorage()
+	app := &App{
+		Storage:  storage,
+		Consumer: newConsumer(),
+		Trending: NewTrendingCache(storage, newTrendingConfig()),
+	}
+	go app.Trending.Run(context.Background())
+
+	if app.Consumer != nil {
+		go func() {
+			err := app.Consumer.Consume(context.Background(), app.handleEvent)
+			fmt.Fprintf(os.Stderr, "Event consumer stopped: %v\n", err)
+			os.Exit(1)
+		}()
+	}
+
+	grpcServer := grpc.NewServer()
+	statistics.RegisterStatisticsServiceServer(grpcServer, &StatisticsServiceServer{App: app})
+
+	// Enable reflection for debugging
+	reflection.Register(grpcServer)
+	port := os.Getenv("STATISTICS_PORT")
+	if port == "" {
+		fmt.Println("STATISTICS_PORT is required")
+		os.Exit(1)
+	}
+
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to listen on port %s: %v\n", port, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("gRPC server is running on port %s\n", port)
+	if err := grpcServer.Serve(listener); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to serve gRPC server: %v\n", err)
+		os.Exit(1)
+	}
+}
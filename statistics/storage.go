@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Storage keeps the counters. Record must ignore an event whose EventId it
+// has already seen, since consumers deliver events at least once.
+type Storage interface {
+	Record(ctx context.Context, event Event) error
+	// GetPostStats returns the counters of the given posts; posts without
+	// events are missing from the map.
+	GetPostStats(ctx context.Context, postIds []string) (map[string]PostStats, error)
+	// TopPosts ranks posts by the sum of metric over events since the given
+	// time, or over all events if since is zero.
+	TopPosts(ctx context.Context, metric Metric, since time.Time, limit int) ([]TopPost, error)
+	// ActivitySince returns the hourly activity buckets starting at or after
+	// the given time.
+	ActivitySince(ctx context.Context, since time.Time) ([]ActivityBucket, error)
+}
+
+// activityBucketSize is the granularity of the per-post activity used for
+// trending.
+const activityBucketSize = time.Hour
+
+// PostgresStorage keeps every event in the events table, all-time counters
+// in post_stats and hourly counters in post_activity.
+type PostgresStorage struct {
+	DB *pgxpool.Pool
+}
+
+func (s *PostgresStorage) Record(ctx context.Context, event Event) error {
+	if !event.Metric.valid() {
+		return fmt.Errorf("unknown metric %q", event.Metric)
+	}
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO events (event_id, metric, post_id, user_id, delta, occurred_at, user_agent, ip)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''))
+		ON CONFLICT (event_id) DO NOTHING`,
+		event.EventId, string(event.Metric), event.PostId, event.UserId, event.Delta, event.OccurredAt.UTC(),
+		event.UserAgent, event.Ip)
+	if err != nil {
+		return fmt.Errorf("failed to insert event: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	// Metrics are checked above, so the column name is one of ours.
+	column := string(event.Metric)
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO post_stats (post_id, %[1]s) VALUES ($1, $2)
+		ON CONFLICT (post_id) DO UPDATE SET %[1]s = post_stats.%[1]s + EXCLUDED.%[1]s`, column),
+		event.PostId, event.Delta)
+	if err != nil {
+		return fmt.Errorf("failed to update post stats: %w", err)
+	}
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO post_activity (post_id, bucket_start, %[1]s) VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, bucket_start) DO UPDATE SET %[1]s = post_activity.%[1]s + EXCLUDED.%[1]s`, column),
+		event.PostId, event.OccurredAt.UTC().Truncate(activityBucketSize), event.Delta)
+	if err != nil {
+		return fmt.Errorf("failed to update post activity: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *PostgresStorage) GetPostStats(ctx context.Context, postIds []string) (map[string]PostStats, error) {
+	rows, err := s.DB.Query(ctx, `
+		SELECT post_id, views, likes, comments FROM post_stats WHERE post_id = ANY($1)`, postIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch post stats: %w", err)
+	}
+	stats, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (PostStats, error) {
+		var s PostStats
+		err := row.Scan(&s.PostId, &s.Views, &s.Likes, &s.Comments)
+		return s, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan post stats: %w", err)
+	}
+
+	result := make(map[string]PostStats, len(stats))
+	for _, s := range stats {
+		result[s.PostId] = s
+	}
+	return result, nil
+}
+
+func (s *PostgresStorage) TopPosts(ctx context.Context, metric Metric, since time.Time, limit int) ([]TopPost, error) {
+	var rows pgx.Rows
+	var err error
+	if since.IsZero() {
+		column := string(metric)
+		rows, err = s.DB.Query(ctx, fmt.Sprintf(`
+			SELECT post_id, %[1]s FROM post_stats
+			WHERE %[1]s > 0
+			ORDER BY %[1]s DESC, post_id
+			LIMIT $1`, column), limit)
+	} else {
+		rows, err = s.DB.Query(ctx, `
+			SELECT post_id, SUM(delta) AS count FROM events
+			WHERE metric = $1 AND occurred_at >= $2
+			GROUP BY post_id
+			HAVING SUM(delta) > 0
+			ORDER BY count DESC, post_id
+			LIMIT $3`, string(metric), since.UTC(), limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch top posts: %w", err)
+	}
+	top, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (TopPost, error) {
+		var t TopPost
+		err := row.Scan(&t.PostId, &t.Count)
+		return t, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan top posts: %w", err)
+	}
+	return top, nil
+}
+
+func (s *PostgresStorage) ActivitySince(ctx context.Context, since time.Time) ([]ActivityBucket, error) {
+	rows, err := s.DB.Query(ctx, `
+		SELECT post_id, bucket_start, views, likes, comments FROM post_activity
+		WHERE bucket_start >= $1`, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch post activity: %w", err)
+	}
+	buckets, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (ActivityBucket, error) {
+		var b ActivityBucket
+		err := row.Scan(&b.PostId, &b.Start, &b.Views, &b.Likes, &b.Comments)
+		return b, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan post activity: %w", err)
+	}
+	return buckets, nil
+}
+
+// MemoryStorage keeps everything in process memory. It is meant for local
+// runs and loses all counters on restart.
+type MemoryStorage struct {
+	mu       sync.Mutex
+	seen     map[string]bool
+	events   []Event
+	stats    map[string]*PostStats
+	activity map[activityKey]*ActivityBucket
+}
+
+type activityKey struct {
+	postId string
+	start  time.Time
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		seen:     make(map[string]bool),
+		stats:    make(map[string]*PostStats),
+		activity: make(map[activityKey]*ActivityBucket),
+	}
+}
+
+func (s *MemoryStorage) Record(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[event.EventId] {
+		return nil
+	}
+	s.seen[event.EventId] = true
+	s.events = append(s.events, event)
+
+	stats, ok := s.stats[event.PostId]
+	if !ok {
+		stats = &PostStats{PostId: event.PostId}
+		s.stats[event.PostId] = stats
+	}
+	*stats.counter(event.Metric) += event.Delta
+
+	key := activityKey{postId: event.PostId, start: event.OccurredAt.UTC().Truncate(activityBucketSize)}
+	bucket, ok := s.activity[key]
+	if !ok {
+		bucket = &ActivityBucket{PostId: key.postId, Start: key.start}
+		s.activity[key] = bucket
+	}
+	*bucket.counter(event.Metric) += event.Delta
+	return nil
+}
+
+func (s *MemoryStorage) GetPostStats(ctx context.Context, postIds []string) (map[string]PostStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]PostStats, len(postIds))
+	for _, postId := range postIds {
+		if stats, ok := s.stats[postId]; ok {
+			result[postId] = *stats
+		}
+	}
+	return result, nil
+}
+
+func (s *MemoryStorage) TopPosts(ctx context.Context, metric Metric, since time.Time, limit int) ([]TopPost, error) {
+	s.mu.Lock()
+	counts := make(map[string]int64)
+	if since.IsZero() {
+		for postId, stats := range s.stats {
+			counts[postId] = *stats.counter(metric)
+		}
+	} else {
+		for _, event := range s.events {
+			if event.Metric == metric && !event.OccurredAt.Before(since) {
+				counts[event.PostId] += event.Delta
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	var top []TopPost
+	for postId, count := range counts {
+		if count > 0 {
+			top = append(top, TopPost{PostId: postId, Count: count})
+		}
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].PostId < top[j].PostId
+	})
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top, nil
+}
+
+func (s *MemoryStorage) ActivitySince(ctx context.Context, since time.Time) ([]ActivityBucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var buckets []ActivityBucket
+	for key, bucket := range s.activity {
+		if !key.start.Before(since) {
+			buckets = append(buckets, *bucket)
+		}
+	}
+	return buckets, nil
+}
+
+func (s *PostStats) counter(metric Metric) *int64 {
+	switch metric {
+	case MetricViews:
+		return &s.Views
+	case MetricLikes:
+		return &s.Likes
+	default:
+		return &s.Comments
+	}
+}
+
+func (b *ActivityBucket) counter(metric Metric) *int64 {
+	switch metric {
+	case MetricViews:
+		return &b.Views
+	case MetricLikes:
+		return &b.Likes
+	default:
+		return &b.Comments
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// trendingWeights is how much one event of each metric adds to a post's
+// trending score: commenting takes more effort than reacting, and reacting
+// more than opening a post.
+var trendingWeights = map[Metric]float64{
+	MetricViews:    1,
+	MetricLikes:    3,
+	MetricComments: 5,
+}
+
+// ActivityBucket holds the counter changes of a post within one hour starting
+// at Start.
+type ActivityBucket struct {
+	PostId   string
+	Start    time.Time
+	Views    int64
+	Likes    int64
+	Comments int64
+}
+
+type TrendingPost struct {
+	PostId string
+	Score  float64
+}
+
+// TrendingConfig controls how posts are ranked. Only activity within Window
+// counts, and activity loses half its weight every HalfLife. Size is how
+// many top posts are kept.
+type TrendingConfig struct {
+	Window          time.Duration
+	HalfLife        time.Duration
+	RefreshInterval time.Duration
+	Size            int
+}
+
+// TrendingCache keeps the trending ranking in memory and recomputes it every
+// RefreshInterval from the activity buckets within the window, so requests
+// never touch the storage and a refresh only reads recently active posts.
+type TrendingCache struct {
+	Storage Storage
+	Config  TrendingConfig
+
+	mu         sync.RWMutex
+	posts      []TrendingPost
+	computedAt time.Time
+}
+
+func NewTrendingCache(storage Storage, config TrendingConfig) *TrendingCache {
+	return &TrendingCache{Storage: storage, Config: config}
+}
+
+// Run refreshes the ranking until ctx is done. A failed refresh keeps the
+// previous ranking.
+func (c *TrendingCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.Config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := c.refresh(ctx); err != nil {
+			fmt.Printf("Failed to refresh trending posts: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *TrendingCache) refresh(ctx context.Context) error {
+	now := time.Now()
+	buckets, err := c.Storage.ActivitySince(ctx, now.Add(-c.Config.Window).Truncate(activityBucketSize))
+	if err != nil {
+		return err
+	}
+	posts := rankTrending(buckets, now, c.Config.HalfLife, c.Config.Size)
+
+	c.mu.Lock()
+	c.posts, c.computedAt = posts, now
+	c.mu.Unlock()
+	return nil
+}
+
+// Get returns up to limit top posts and when they were ranked. computedAt is
+// zero until the first refresh succeeds.
+func (c *TrendingCache) Get(limit int) (posts []TrendingPost, computedAt time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if limit > len(c.posts) {
+		limit = len(c.posts)
+	}
+	return c.posts[:limit], c.computedAt
+}
+
+// rankTrending sums the weighted activity of every post, decaying each
+// bucket by its age, and returns the size posts with the highest positive
+// score.
+func rankTrending(buckets []ActivityBucket, now time.Time, halfLife time.Duration, size int) []TrendingPost {
+	scores := make(map[string]float64)
+	for _, bucket := range buckets {
+		weight := trendingWeights[MetricViews]*float64(bucket.Views) +
+			trendingWeights[MetricLikes]*float64(bucket.Likes) +
+			trendingWeights[MetricComments]*float64(bucket.Comments)
+		// Age is taken from the middle of the bucket.
+		age := now.Sub(bucket.Start.Add(activityBucketSize / 2))
+		if age < 0 {
+			age = 0
+		}
+		scores[bucket.PostId] += weight * math.Exp2(-age.Hours()/halfLife.Hours())
+	}
+
+	posts := make([]TrendingPost, 0, len(scores))
+	for postId, score := range scores {
+		if score > 0 {
+			posts = append(posts, TrendingPost{PostId: postId, Score: score})
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].Score != posts[j].Score {
+			return posts[i].Score > posts[j].Score
+		}
+		return posts[i].PostId < posts[j].PostId
+	})
+	if len(posts) > size {
+		posts = posts[:size]
+	}
+	return posts
+}
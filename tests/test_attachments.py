@@ -0,0 +1,279 @@
+import hashlib
+import struct
+import time
+import zlib
+
+import psycopg
+import requests
+from utils import API_GATEWAY_URL, POSTS_DATABASE_URL, WithDeletePosts, create_post, register_and_login
+
+MAX_UPLOAD_SIZE = 1048576
+
+
+def make_png(width, height):
+    def chunk(kind, data):
+        return struct.pack(">I", len(data)) + kind + data + struct.pack(">I", zlib.crc32(kind + data))
+
+    rows = b"".join(b"\x00" + bytes([x % 256, 0, 128]) * width for x in range(height))
+    header = struct.pack(">IIBBBBB", width, height, 8, 2, 0, 0, 0)
+    return b"\x89PNG\r\n\x1a\n" + chunk(b"IHDR", header) + chunk(b"IDAT", zlib.compress(rows)) + chunk(b"IEND", b"")
+
+
+def upload(token, filename, content):
+    return requests.post(
+        f"{API_GATEWAY_URL}/attachments",
+        headers={"Authorization": token},
+        files={"file": (filename, content, "application/octet-stream")},
+    )
+
+
+def cleanup():
+    return WithDeletePosts(
+        "DELETE FROM posts WHERE title LIKE 'Attachment Post%'; "
+        "DELETE FROM attachments WHERE filename LIKE 'test-%'; "
+        "DELETE FROM uploads WHERE filename LIKE 'test-%'"
+    )
+
+
+def test_upload_image_and_attach_to_post():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with cleanup():
+                content = make_png(400, 200)
+                response = upload(token, "test-image.png", content)
+                assert response.status_code == 201, response.text
+                attachment = response.json()
+                assert attachment["mime_type"] == "image/png"
+                assert attachment["size"] == len(content)
+                assert (attachment["width"], attachment["height"]) == (400, 200)
+                assert attachment["checksum"] == hashlib.sha256(content).hexdigest()
+                assert attachment["filename"] == "test-image.png"
+
+                response = requests.get(f"{API_GATEWAY_URL}{attachment['url']}", headers={"Authorization": token})
+                assert response.status_code == 200, response.text
+                assert response.content == content
+                assert response.headers["Content-Type"] == "image/png"
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}{attachment['thumbnail_url']}", headers={"Authorization": token}
+                )
+                assert response.status_code == 200, response.text
+                assert response.content.startswith(b"\x89PNG")
+                width, height = struct.unpack(">II", response.content[16:24])
+                assert (width, height) == (320, 160)
+
+                # Unattached uploads are private to their owner.
+                response = requests.get(f"{API_GATEWAY_URL}{attachment['url']}", headers={"Authorization": other})
+                assert response.status_code == 404, response.text
+
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": other},
+                    json={
+                        "title": "Attachment Post Stolen",
+                        "description": "",
+                        "is_private": False,
+                        "attachment_ids": [attachment["attachment_id"]],
+                    },
+                )
+                assert response.status_code == 400, response.text
+
+                post = create_post(token, "Attachment Post", attachment_ids=[attachment["attachment_id"]])
+                assert [a["attachment_id"] for a in post["attachments"]] == [attachment["attachment_id"]]
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/{post['post_id']}", headers={"Authorization": other})
+                assert response.status_code == 200, response.text
+                assert response.json()["attachments"][0]["checksum"] == attachment["checksum"]
+                response = requests.get(f"{API_GATEWAY_URL}{attachment['url']}", headers={"Authorization": other})
+                assert response.status_code == 200, response.text
+
+                # An attachment belongs to one post only.
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": token},
+                    json={
+                        "title": "Attachment Post 2",
+                        "description": "",
+                        "is_private": False,
+                        "attachment_ids": [attachment["attachment_id"]],
+                    },
+                )
+                assert response.status_code == 400, response.text
+
+                response = requests.patch(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}",
+                    headers={"Authorization": token},
+                    json={"is_private": True},
+                )
+                assert response.status_code == 200, response.text
+                assert len(response.json()["attachments"]) == 1
+                response = requests.get(f"{API_GATEWAY_URL}{attachment['url']}", headers={"Authorization": other})
+                assert response.status_code == 404, response.text
+
+                response = requests.patch(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}",
+                    headers={"Authorization": token},
+                    json={"attachment_ids": []},
+                )
+                assert response.status_code == 200, response.text
+                assert response.json()["attachments"] == []
+
+
+def test_content_type_is_sniffed_and_size_is_limited():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with cleanup():
+            response = upload(token, "test-notes.png", b"just some notes\n")
+            assert response.status_code == 201, response.text
+            attachment = response.json()
+            assert attachment["mime_type"] == "text/plain; charset=utf-8"
+            assert "thumbnail_url" not in attachment
+            assert "width" not in attachment
+
+            response = upload(token, "test-program.png", b"\x7fELF\x02\x01\x01" + b"\x00" * 64)
+            assert response.status_code == 415, response.text
+
+            response = upload(token, "test-empty.txt", b"")
+            assert response.status_code == 400, response.text
+
+            response = upload(token, "test-large.txt", b"a" * (MAX_UPLOAD_SIZE + 1))
+            assert response.status_code == 413, response.text
+
+
+def test_chunked_upload():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with cleanup():
+                content = make_png(64, 64)
+                half = len(content) // 2
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/uploads",
+                    headers={"Authorization": token},
+                    json={
+                        "filename": "test-chunked.png",
+                        "size": len(content),
+                        "checksum": hashlib.sha256(content).hexdigest(),
+                    },
+                )
+                assert response.status_code == 201, response.text
+                upload_id = response.json()["upload_id"]
+
+                def put_chunk(first, last, headers={"Authorization": token}):
+                    return requests.put(
+                        f"{API_GATEWAY_URL}/uploads/{upload_id}",
+                        headers={**headers, "Content-Range": f"bytes {first}-{last}/{len(content)}"},
+                        data=content[first : last + 1],
+                    )
+
+                response = put_chunk(0, half - 1)
+                assert response.status_code == 200, response.text
+                assert response.json()["received"] == half
+
+                # Repeating a chunk, e.g. after a lost response, is rejected.
+                response = put_chunk(0, half - 1)
+                assert response.status_code == 409, response.text
+
+                response = requests.get(f"{API_GATEWAY_URL}/uploads/{upload_id}", headers={"Authorization": other})
+                assert response.status_code == 404, response.text
+                response = requests.get(f"{API_GATEWAY_URL}/uploads/{upload_id}", headers={"Authorization": token})
+                assert response.status_code == 200, response.text
+                assert response.json()["received"] == half
+
+                response = put_chunk(half, len(content) - 1)
+                assert response.status_code == 201, response.text
+                attachment = response.json()
+                assert attachment["filename"] == "test-chunked.png"
+                assert attachment["checksum"] == hashlib.sha256(content).hexdigest()
+                assert (attachment["width"], attachment["height"]) == (64, 64)
+
+                response = requests.get(f"{API_GATEWAY_URL}{attachment['url']}", headers={"Authorization": token})
+                assert response.content == content
+
+                response = requests.get(f"{API_GATEWAY_URL}/uploads/{upload_id}", headers={"Authorization": token})
+                assert response.status_code == 404, response.text
+
+
+def create_upload(token, filename, content, checksum):
+    response = requests.post(
+        f"{API_GATEWAY_URL}/uploads",
+        headers={"Authorization": token},
+        json={"filename": filename, "size": len(content), "checksum": checksum},
+    )
+    assert response.status_code == 201, response.text
+    return response.json()["upload_id"]
+
+
+def execute_posts(query, params):
+    with psycopg.connect(POSTS_DATABASE_URL) as conn:
+        with conn.cursor() as cur:
+            cur.execute(query, params)
+            conn.commit()
+
+
+def test_failed_upload_completion_can_be_retried():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with cleanup():
+            content = make_png(32, 32)
+            upload_id = create_upload(token, "test-retried.png", content, "0" * 64)
+
+            def put(content_range, data=b""):
+                return requests.put(
+                    f"{API_GATEWAY_URL}/uploads/{upload_id}",
+                    headers={"Authorization": token, "Content-Range": content_range},
+                    data=data,
+                )
+
+            response = put(f"bytes */{len(content)}")
+            assert response.status_code == 409, response.text
+
+            response = put(f"bytes 0-{len(content) - 1}/{len(content)}", content)
+            assert response.status_code == 400, response.text
+            response = requests.get(f"{API_GATEWAY_URL}/uploads/{upload_id}", headers={"Authorization": token})
+            assert response.status_code == 200, response.text
+            assert response.json()["received"] == len(content)
+
+            # The chunks are kept, so finishing can be repeated once the
+            # cause of the failure is gone.
+            response = put(f"bytes 0-{len(content) - 1}/{len(content)}", content)
+            assert response.status_code == 409, response.text
+            execute_posts(
+                "UPDATE uploads SET checksum = %s WHERE upload_id = %s",
+                (hashlib.sha256(content).hexdigest(), upload_id),
+            )
+            response = put(f"bytes */{len(content)}")
+            assert response.status_code == 201, response.text
+            attachment = response.json()
+            response = requests.get(f"{API_GATEWAY_URL}{attachment['url']}", headers={"Authorization": token})
+            assert response.content == content
+
+
+def test_abandoned_uploads_and_unattached_attachments_are_removed():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with cleanup():
+            response = upload(token, "test-abandoned.txt", b"never attached")
+            assert response.status_code == 201, response.text
+            attachment = response.json()
+            upload_id = create_upload(token, "test-abandoned.bin", b"never finished", "")
+
+            execute_posts(
+                "UPDATE attachments SET detached_at = detached_at - INTERVAL '2 days' WHERE attachment_id = %s",
+                (attachment["attachment_id"],),
+            )
+            execute_posts(
+                "UPDATE uploads SET last_chunk_at = last_chunk_at - INTERVAL '2 days' WHERE upload_id = %s",
+                (upload_id,),
+            )
+
+            deadline = time.time() + 15
+            while True:
+                attachment_response = requests.get(
+                    f"{API_GATEWAY_URL}{attachment['url']}", headers={"Authorization": token}
+                )
+                upload_response = requests.get(
+                    f"{API_GATEWAY_URL}/uploads/{upload_id}", headers={"Authorization": token}
+                )
+                done = attachment_response.status_code == 404 and upload_response.status_code == 404
+                if done or time.time() > deadline:
+                    break
+                time.sleep(1)
+            assert attachment_response.status_code == 404, attachment_response.text
+            assert upload_response.status_code == 404, upload_response.text
@@ -0,0 +1,64 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, register_and_login
+
+
+def test_expand_author_on_posts_and_comments():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Authored Post'"):
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": owner},
+                    params={"expand": "author"},
+                    json={"title": "Authored Post", "description": "", "is_private": False, "tags": ["authors-test"]},
+                )
+                assert response.status_code == 201, response.text
+                post = response.json()
+                assert post["author"]["login"] == "testuser"
+                assert post["author"]["user_id"] == post["creator_id"]
+                assert post["author"]["display_name"] == "Test User"
+                assert "email" not in post["author"]
+
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}/comments",
+                    headers={"Authorization": other},
+                    json={"content": "Hi"},
+                )
+                assert response.status_code == 201, response.text
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": other},
+                    params={"tags": "authors-test", "expand": "author"},
+                )
+                assert response.status_code == 200, response.text
+                [listed] = response.json()["posts"]
+                assert listed["author"]["login"] == "testuser"
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}/comments",
+                    headers={"Authorization": owner},
+                    params={"expand": "author"},
+                )
+                assert response.status_code == 200, response.text
+                [comment] = response.json()["comments"]
+                assert comment["author"]["login"] == "otheruser"
+
+                # Without expand only the id is returned.
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}",
+                    headers={"Authorization": owner},
+                )
+                assert response.status_code == 200, response.text
+                assert "author" not in response.json()
+
+
+def test_expand_unknown_field():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/posts",
+            headers={"Authorization": token},
+            params={"expand": "author,secrets"},
+        )
+        assert response.status_code == 400, response.text
+        assert response.json()["code"] == "INVALID_ARGUMENT"
@@ -0,0 +1,116 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def test_comment_thread():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Commented Post'"):
+            post_id = create_post(token, "Commented Post")["post_id"]
+
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                headers={"Authorization": token},
+                json={"content": "First!"},
+            )
+            assert response.status_code == 201, response.text
+            comment = response.json()
+            assert comment["content"] == "First!"
+            assert comment["post_id"] == post_id
+
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                headers={"Authorization": token},
+                json={"content": "A reply", "parent_comment_id": comment["comment_id"]},
+            )
+            assert response.status_code == 201, response.text
+            reply = response.json()
+            assert reply["parent_comment_id"] == comment["comment_id"]
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                headers={"Authorization": token},
+            )
+            assert response.status_code == 200, response.text
+            data = response.json()
+            assert [c["comment_id"] for c in data["comments"]] == [comment["comment_id"]]
+            assert data["comments"][0]["reply_count"] == 1
+            assert data["next_cursor"] == ""
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                headers={"Authorization": token},
+                params={"parent_comment_id": comment["comment_id"]},
+            )
+            assert response.status_code == 200, response.text
+            assert [c["comment_id"] for c in response.json()["comments"]] == [reply["comment_id"]]
+
+            response = requests.put(
+                f"{API_GATEWAY_URL}/posts/{post_id}/comments/{reply['comment_id']}",
+                headers={"Authorization": token},
+                json={"content": "An edited reply"},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json()["content"] == "An edited reply"
+
+            response = requests.delete(
+                f"{API_GATEWAY_URL}/posts/{post_id}/comments/{comment['comment_id']}",
+                headers={"Authorization": token},
+            )
+            assert response.status_code == 200, response.text
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                headers={"Authorization": token},
+                params={"parent_comment_id": comment["comment_id"]},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json()["comments"] == []
+
+
+def test_comments_pagination():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Busy Post'"):
+            post_id = create_post(token, "Busy Post")["post_id"]
+            for i in range(5):
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                    headers={"Authorization": token},
+                    json={"content": f"Comment {i}"},
+                )
+                assert response.status_code == 201, response.text
+
+            contents = []
+            cursor = ""
+            while True:
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                    headers={"Authorization": token},
+                    params={"limit": 2, "cursor": cursor},
+                )
+                assert response.status_code == 200, response.text
+                data = response.json()
+                contents += [c["content"] for c in data["comments"]]
+                cursor = data["next_cursor"]
+                if not cursor:
+                    break
+            assert contents == [f"Comment {i}" for i in range(5)]
+
+
+def test_private_post_comments_hidden():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Secret Post'"):
+                post_id = create_post(owner, "Secret Post", is_private=True)["post_id"]
+
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                    headers={"Authorization": other},
+                    json={"content": "Let me in"},
+                )
+                assert response.status_code != 201, response.text
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code != 200, response.text
@@ -0,0 +1,122 @@
+import time
+from datetime import datetime, timedelta, timezone
+
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def listed_post_ids(token):
+    response = requests.get(
+        f"{API_GATEWAY_URL}/posts", headers={"Authorization": token}, params={"tags": "drafts-test"}
+    )
+    assert response.status_code == 200, response.text
+    return [post["post_id"] for post in response.json()["posts"]]
+
+
+def test_draft_is_visible_only_to_creator_until_published():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Draft Post'"):
+                post = create_post(token, "Draft Post", tags=["drafts-test"], status="draft")
+                assert post["status"] == "draft"
+                assert "publish_at" not in post
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/{post['post_id']}", headers={"Authorization": token})
+                assert response.status_code == 200, response.text
+                response = requests.get(f"{API_GATEWAY_URL}/posts/{post['post_id']}", headers={"Authorization": other})
+                assert response.status_code == 404, response.text
+                assert post["post_id"] not in listed_post_ids(token)
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/drafts", headers={"Authorization": token})
+                assert response.status_code == 200, response.text
+                assert [p["post_id"] for p in response.json()["posts"]] == [post["post_id"]]
+
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}/publish", headers={"Authorization": other}
+                )
+                assert response.status_code == 404, response.text
+
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}/publish", headers={"Authorization": token}
+                )
+                assert response.status_code == 200, response.text
+                published = response.json()
+                assert published["status"] == "published"
+                assert published["publish_at"]
+                assert published["created_at"] == post["created_at"]
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/{post['post_id']}", headers={"Authorization": other})
+                assert response.status_code == 200, response.text
+                assert post["post_id"] in listed_post_ids(other)
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/drafts", headers={"Authorization": token})
+                assert response.json()["posts"] == []
+
+
+def test_scheduled_post_is_published_by_scheduler():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Scheduled Post'"):
+                publish_at = datetime.now(timezone.utc) + timedelta(seconds=3)
+                post = create_post(token, "Scheduled Post", tags=["drafts-test"], status="scheduled", publish_at=publish_at.isoformat())
+                assert post["status"] == "scheduled"
+                assert post["post_id"] not in listed_post_ids(other)
+
+                for _ in range(20):
+                    time.sleep(1)
+                    response = requests.get(
+                        f"{API_GATEWAY_URL}/posts/{post['post_id']}", headers={"Authorization": other}
+                    )
+                    if response.status_code == 200:
+                        break
+                assert response.status_code == 200, response.text
+                assert response.json()["status"] == "published"
+                # The post keeps the requested time even if the scheduler ran later.
+                assert response.json()["publish_at"] == post["publish_at"]
+                assert response.json()["created_at"] == post["created_at"]
+                assert post["post_id"] in listed_post_ids(other)
+
+
+def test_published_draft_is_listed_by_publish_time():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Ordering %'"):
+            draft = create_post(token, "Ordering Draft", tags=["drafts-test"], status="draft")
+            post = create_post(token, "Ordering Post", tags=["drafts-test"])
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts/{draft['post_id']}/publish", headers={"Authorization": token}
+            )
+            assert response.status_code == 200, response.text
+
+            # The draft was started first but published last.
+            assert listed_post_ids(token) == [post["post_id"], draft["post_id"]]
+
+
+def test_scheduling_published_post_conflicts():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Published Post'"):
+            post = create_post(token, "Published Post", tags=["drafts-test"])
+            publish_at = datetime.now(timezone.utc) + timedelta(hours=1)
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}/publish",
+                headers={"Authorization": token},
+                json={"publish_at": publish_at.isoformat()},
+            )
+            assert response.status_code == 409, response.text
+            assert response.json()["code"] == "ABORTED"
+            assert response.json()["details"][0]["reason"] == "POST_PUBLISHED"
+
+
+def test_invalid_status():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        for body in (
+            {"status": "hidden"},
+            {"status": "scheduled"},
+            {"status": "scheduled", "publish_at": "2000-01-01T00:00:00Z"},
+            {"status": "draft", "publish_at": "2100-01-01T00:00:00Z"},
+        ):
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts",
+                headers={"Authorization": token},
+                json={"title": "Invalid Draft", "description": "", **body},
+            )
+            assert response.status_code == 400, response.text
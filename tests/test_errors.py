@@ -0,0 +1,83 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def test_missing_post_is_not_found():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/posts/00000000-0000-0000-0000-000000000000",
+            headers={"Authorization": token},
+        )
+        assert response.status_code == 404, response.text
+        data = response.json()
+        assert data["code"] == "NOT_FOUND"
+        assert data["request_id"] == response.headers["X-Request-Id"]
+        assert data["details"] == [
+            {"resource_type": "post", "resource_name": "00000000-0000-0000-0000-000000000000"}
+        ]
+
+
+def test_request_id_is_echoed():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/posts/00000000-0000-0000-0000-000000000000",
+            headers={"Authorization": token, "X-Request-Id": "test-request-id"},
+        )
+        assert response.headers["X-Request-Id"] == "test-request-id"
+        assert response.json()["request_id"] == "test-request-id"
+
+
+def test_private_post_of_other_user_is_not_found():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Errors %'"):
+                post_id = create_post(owner, "Errors Private", is_private=True)["post_id"]
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post_id}",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code == 404, response.text
+                assert response.json()["code"] == "NOT_FOUND"
+
+
+def test_foreign_post_is_forbidden():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Errors %'"):
+                post_id = create_post(owner, "Errors Foreign")["post_id"]
+                response = requests.delete(
+                    f"{API_GATEWAY_URL}/posts/{post_id}",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code == 403, response.text
+                data = response.json()
+                assert data["code"] == "PERMISSION_DENIED"
+                assert data["details"] == [{"reason": "NOT_POST_CREATOR"}]
+
+                response = requests.put(
+                    f"{API_GATEWAY_URL}/posts/{post_id}",
+                    headers={"Authorization": other},
+                    json={"title": "Errors Hijacked", "description": "", "is_private": False},
+                )
+                assert response.status_code == 403, response.text
+
+
+def test_invalid_argument_has_field_details():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Errors %'"):
+            post_id = create_post(token, "Errors Reaction")["post_id"]
+            response = requests.put(
+                f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                headers={"Authorization": token},
+                json={"reaction_type": "meh"},
+            )
+            assert response.status_code == 400, response.text
+            data = response.json()
+            assert data["code"] == "INVALID_ARGUMENT"
+            assert [d["field"] for d in data["details"]] == ["reaction_type"]
+
+
+def test_unauthorized_has_error_code():
+    response = requests.get(f"{API_GATEWAY_URL}/posts")
+    assert response.status_code == 401, response.text
+    assert response.json()["code"] == "UNAUTHENTICATED"
@@ -0,0 +1,92 @@
+import time
+import psycopg
+import requests
+from utils import (
+    API_GATEWAY_URL,
+    POSTS_DATABASE_URL,
+    STATISTICS_DATABASE_URL,
+    WithDeletePosts,
+    WithDeleteStatistics,
+    register_and_login,
+)
+
+
+def wait_for(query, params, expected, database_url, timeout=15):
+    deadline = time.time() + timeout
+    while True:
+        with psycopg.connect(database_url) as conn:
+            with conn.cursor() as cur:
+                cur.execute(query, params)
+                row = cur.fetchone()
+        if row == expected or time.time() > deadline:
+            return row
+        time.sleep(0.5)
+
+
+def test_mutations_are_written_to_outbox_and_counted():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Events Post'"):
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": owner},
+                    json={"title": "Events Post", "description": "", "is_private": False},
+                )
+                assert response.status_code == 201, response.text
+                post_id = response.json()["post_id"]
+
+                with WithDeleteStatistics(f"DELETE FROM post_stats WHERE post_id = '{post_id}'"):
+                    for token, reaction_type in ((owner, "like"), (other, "love"), (other, "wow")):
+                        response = requests.put(
+                            f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                            headers={"Authorization": token},
+                            json={"reaction_type": reaction_type},
+                        )
+                        assert response.status_code == 200, response.text
+                    response = requests.delete(
+                        f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                        headers={"Authorization": owner},
+                    )
+                    assert response.status_code == 200, response.text
+
+                    response = requests.post(
+                        f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                        headers={"Authorization": other},
+                        json={"content": "first"},
+                    )
+                    assert response.status_code == 201, response.text
+                    comment_id = response.json()["comment_id"]
+                    for content in ("reply", "another reply"):
+                        response = requests.post(
+                            f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                            headers={"Authorization": owner},
+                            json={"content": content, "parent_comment_id": comment_id},
+                        )
+                        assert response.status_code == 201, response.text
+                    response = requests.post(
+                        f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                        headers={"Authorization": owner},
+                        json={"content": "second"},
+                    )
+                    assert response.status_code == 201, response.text
+                    response = requests.delete(
+                        f"{API_GATEWAY_URL}/posts/{post_id}/comments/{comment_id}",
+                        headers={"Authorization": other},
+                    )
+                    assert response.status_code == 200, response.text
+
+                    # The relay empties the outbox once events are published.
+                    remaining = wait_for(
+                        "SELECT COUNT(*) FROM outbox WHERE post_id = %s", (post_id,), (0,), POSTS_DATABASE_URL
+                    )
+                    assert remaining == (0,)
+
+                    # One reaction is left, and one of four comments.
+                    stats = wait_for(
+                        "SELECT likes, comments FROM post_stats WHERE post_id = %s",
+                        (post_id,),
+                        (1, 1),
+                        STATISTICS_DATABASE_URL,
+                    )
+                    assert stats == (1, 1)
+
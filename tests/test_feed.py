@@ -0,0 +1,51 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def user_id_of(login, password):
+    response = requests.post(
+        f"{API_GATEWAY_URL}/passport/login",
+        json={"login": login, "password": password},
+    )
+    assert response.status_code == 200, response.text
+    return response.json()["user_id"]
+
+
+def feed_titles(token, limit=100):
+    titles = []
+    cursor = ""
+    while True:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/feed",
+            headers={"Authorization": token},
+            params={"limit": limit, "cursor": cursor},
+        )
+        assert response.status_code == 200, response.text
+        data = response.json()
+        titles += [p["title"] for p in data["posts"] if p["title"].startswith("Feed ")]
+        cursor = data["next_cursor"]
+        if not cursor:
+            return titles
+
+
+def test_feed():
+    with register_and_login("testuser", "mail@example.com", "password") as reader:
+        with register_and_login("otheruser", "other@example.com", "password") as author:
+            with register_and_login("strangeruser", "stranger@example.com", "password") as stranger:
+                with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Feed %'"):
+                    author_id = user_id_of("otheruser", "password")
+                    response = requests.post(
+                        f"{API_GATEWAY_URL}/passport/users/{author_id}/follow",
+                        headers={"Authorization": reader},
+                    )
+                    assert response.status_code == 200, response.text
+
+                    create_post(author, "Feed Followed 1")
+                    create_post(stranger, "Feed Stranger")
+                    create_post(author, "Feed Followed Private", is_private=True)
+                    create_post(reader, "Feed Own")
+                    create_post(author, "Feed Followed 2")
+
+                    expected = ["Feed Followed 2", "Feed Own", "Feed Followed 1"]
+                    assert feed_titles(reader) == expected
+                    assert feed_titles(reader, limit=1) == expected
@@ -0,0 +1,28 @@
+import base64
+import json
+import requests
+from utils import API_GATEWAY_URL, register_and_login
+
+
+def token_header(token):
+    header = token.split(".")[0]
+    return json.loads(base64.urlsafe_b64decode(header + "=" * (-len(header) % 4)))
+
+
+def test_jwks_lists_signing_key():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(f"{API_GATEWAY_URL}/passport/.well-known/jwks.json")
+        assert response.status_code == 200, response.text
+        keys = {key["kid"]: key for key in response.json()["keys"]}
+
+        header = token_header(token)
+        assert header["alg"] in ("RS256", "EdDSA")
+        assert header["kid"] in keys
+        assert keys[header["kid"]]["alg"] == header["alg"]
+
+
+def test_jwks_has_no_private_material():
+    response = requests.get(f"{API_GATEWAY_URL}/passport/.well-known/jwks.json")
+    assert response.status_code == 200, response.text
+    for key in response.json()["keys"]:
+        assert "d" not in key
@@ -0,0 +1,28 @@
+import requests
+from utils import API_GATEWAY_URL, register_and_login
+
+
+def test_revoked_session_rejected_by_posts_routes():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(f"{API_GATEWAY_URL}/posts", headers={"Authorization": token})
+        assert response.status_code == 200, response.text
+
+        response = requests.post(f"{API_GATEWAY_URL}/passport/logout", headers={"Authorization": token})
+        assert response.status_code == 200, response.text
+
+        response = requests.get(f"{API_GATEWAY_URL}/posts", headers={"Authorization": token})
+        assert response.status_code == 401, response.text
+
+
+def test_bearer_prefix_accepted():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": f"Bearer {token}"})
+        assert response.status_code == 200, response.text
+
+
+def test_tampered_token_rejected():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        header, payload, signature = token.split(".")
+        tampered = ".".join([header, payload, signature[::-1]])
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": tampered})
+        assert response.status_code == 401, response.text
@@ -1,7 +1,7 @@
 import requests
 import psycopg
 import datetime
-from utils import WithDeletePassport, API_GATEWAY_URL, PASSPORT_DATABASE_URL
+from utils import WithDeletePassport, API_GATEWAY_URL, PASSPORT_DATABASE_URL, register_and_login
 
 
 def test_e2e_passport():
@@ -68,6 +68,9 @@ def test_e2e_passport():
             "surname": "User",
             "date_of_birth": "1990-01-01",
             "phone_number": "+1234567890",
+            "display_name": "",
+            "avatar_url": "",
+            "bio": "",
         }
         response = requests.put(
             f"{API_GATEWAY_URL}/passport/me",
@@ -106,3 +109,35 @@ def test_e2e_passport():
                 updated_at = updated_at.replace(tzinfo=datetime.timezone.utc)
                 delta = datetime.timedelta(seconds=10)
                 assert now - delta < updated_at < now + delta
+
+
+def test_register_duplicate_login():
+    with register_and_login("testuser", "mail@example.com", "password"):
+        response = requests.post(
+            f"{API_GATEWAY_URL}/passport/register",
+            json={
+                "login": "testuser",
+                "email": "other@example.com",
+                "password": "password",
+                "name": "Test",
+                "surname": "User",
+                "date_of_birth": "1990-01-01",
+                "phone_number": "+1234567890",
+            },
+        )
+        assert response.status_code == 409, response.text
+
+
+def test_login_wrong_password():
+    with register_and_login("testuser", "mail@example.com", "password"):
+        response = requests.post(
+            f"{API_GATEWAY_URL}/passport/login",
+            json={"login": "testuser", "password": "wrong"},
+        )
+        assert response.status_code == 401, response.text
+
+        response = requests.post(
+            f"{API_GATEWAY_URL}/passport/login",
+            json={"login": "nosuchuser", "password": "password"},
+        )
+        assert response.status_code == 401, response.text
@@ -0,0 +1,77 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def patch_post(token, post_id, body, if_match=None):
+    headers = {"Authorization": token, "Content-Type": "application/merge-patch+json"}
+    if if_match is not None:
+        headers["If-Match"] = if_match
+    return requests.patch(f"{API_GATEWAY_URL}/posts/{post_id}", headers=headers, json=body)
+
+
+def test_patch_changes_only_given_fields():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Patched Post'"):
+            post = create_post(token, "Patched Post", description="Kept description.", tags=["patch"])
+
+            response = patch_post(token, post["post_id"], {"is_private": True})
+            assert response.status_code == 200, response.text
+            data = response.json()
+            assert data["is_private"]
+            assert data["title"] == "Patched Post"
+            assert data["description"] == "Kept description."
+            assert data["tags"] == ["patch"]
+            assert data["updated_at"] != post["updated_at"]
+
+            # null resets a field.
+            response = patch_post(token, post["post_id"], {"tags": None})
+            assert response.status_code == 200, response.text
+            assert response.json()["tags"] == []
+            assert response.json()["is_private"]
+
+
+def test_patch_rejects_bad_input():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Patched Post'"):
+            post = create_post(token, "Patched Post", description="Kept description.", tags=["patch"])
+
+            for body in [{}, {"creator_id": "someone"}, {"is_private": "yes"}]:
+                response = patch_post(token, post["post_id"], body)
+                assert response.status_code == 400, (body, response.text)
+                assert response.json()["code"] == "INVALID_ARGUMENT"
+
+
+def test_if_match_detects_concurrent_edit():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Patched Post'"):
+            post = create_post(token, "Patched Post", description="Kept description.", tags=["patch"])
+
+            response = requests.get(f"{API_GATEWAY_URL}/posts/{post['post_id']}", headers={"Authorization": token})
+            assert response.status_code == 200, response.text
+            etag = response.headers["ETag"]
+
+            response = patch_post(token, post["post_id"], {"description": "First edit."}, if_match=etag)
+            assert response.status_code == 200, response.text
+            new_etag = response.headers["ETag"]
+            assert new_etag != etag
+
+            # The second editor read the post before the first edit.
+            response = patch_post(token, post["post_id"], {"description": "Second edit."}, if_match=etag)
+            assert response.status_code == 412, response.text
+            data = response.json()
+            assert data["code"] == "FAILED_PRECONDITION"
+            assert data["details"] == [{"reason": "POST_MODIFIED"}]
+
+            response = requests.put(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}",
+                headers={"Authorization": token, "If-Match": etag},
+                json={"title": "Patched Post", "description": "Second edit.", "is_private": False},
+            )
+            assert response.status_code == 412, response.text
+
+            response = patch_post(token, post["post_id"], {"description": "Second edit."}, if_match=new_etag)
+            assert response.status_code == 200, response.text
+            assert response.json()["description"] == "Second edit."
+
+            response = patch_post(token, post["post_id"], {"description": "Bad tag."}, if_match="W/\"1\"")
+            assert response.status_code == 412, response.text
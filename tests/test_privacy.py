@@ -0,0 +1,72 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def list_all_posts(token, **params):
+    posts = []
+    page_token = ""
+    while True:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/posts",
+            headers={"Authorization": token},
+            params={"limit": 100, "page_token": page_token, **params},
+        )
+        assert response.status_code == 200, response.text
+        data = response.json()
+        posts += data["posts"]
+        page_token = data["next_page_token"]
+        if not page_token:
+            return posts, data["total_count"]
+
+
+def test_private_posts_do_not_leak_through_listing():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Privacy %'"):
+                public_id = create_post(owner, "Privacy Public", tags=["privacy-test"])["post_id"]
+                private_id = create_post(owner, "Privacy Private", is_private=True, tags=["privacy-test"])["post_id"]
+
+                posts, total_count = list_all_posts(other)
+                ids = {p["post_id"] for p in posts}
+                assert public_id in ids
+                assert private_id not in ids
+                assert all(not p["is_private"] for p in posts)
+
+                posts, total_count = list_all_posts(other, tags="privacy-test")
+                assert [p["post_id"] for p in posts] == [public_id]
+                assert total_count == 1
+
+                posts, total_count = list_all_posts(owner, tags="privacy-test")
+                assert {p["post_id"] for p in posts} == {public_id, private_id}
+                assert total_count == 2
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{private_id}",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code == 404, response.text
+
+
+def test_post_turned_private_disappears_from_listing():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Privacy %'"):
+                post_id = create_post(owner, "Privacy Flip", tags=["privacy-test"])["post_id"]
+                posts, _ = list_all_posts(other, tags="privacy-test")
+                assert [p["post_id"] for p in posts] == [post_id]
+
+                response = requests.put(
+                    f"{API_GATEWAY_URL}/posts/{post_id}",
+                    headers={"Authorization": owner},
+                    json={
+                        "title": "Privacy Flip",
+                        "description": "Privacy test post.",
+                        "is_private": True,
+                        "tags": ["privacy-test"],
+                    },
+                )
+                assert response.status_code == 200, response.text
+
+                posts, total_count = list_all_posts(other, tags="privacy-test")
+                assert posts == []
+                assert total_count == 0
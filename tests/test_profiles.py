@@ -0,0 +1,94 @@
+import requests
+from utils import API_GATEWAY_URL, register_and_login
+
+
+PRIVATE_FIELDS = {"email", "phone_number", "date_of_birth"}
+
+
+def update_me(token, **fields):
+    body = {
+        "email": "mail@example.com",
+        "name": "Test",
+        "surname": "User",
+        "date_of_birth": "1990-01-01",
+        "phone_number": "+1234567890",
+    }
+    body.update(fields)
+    return requests.put(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": token}, json=body)
+
+
+def get_user_id(token):
+    response = requests.get(f"{API_GATEWAY_URL}/passport/users/by-login/testuser", headers={"Authorization": token})
+    assert response.status_code == 200, response.text
+    return response.json()["user_id"]
+
+
+def test_public_profile():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            user_id = get_user_id(token)
+
+            response = requests.get(f"{API_GATEWAY_URL}/passport/users/{user_id}", headers={"Authorization": other})
+            assert response.status_code == 200, response.text
+            profile = response.json()
+            assert not PRIVATE_FIELDS & profile.keys()
+            assert profile["login"] == "testuser"
+            # Falls back to the name and surname.
+            assert profile["display_name"] == "Test User"
+            assert profile["followers_count"] == 0
+
+            response = update_me(token, display_name="Tester", avatar_url="https://example.com/a.png", bio="Hello")
+            assert response.status_code == 200, response.text
+            response = requests.post(f"{API_GATEWAY_URL}/passport/users/{user_id}/follow", headers={"Authorization": other})
+            assert response.status_code == 200, response.text
+
+            response = requests.get(f"{API_GATEWAY_URL}/passport/users/by-login/testuser", headers={"Authorization": other})
+            assert response.status_code == 200, response.text
+            profile = response.json()
+            assert profile["user_id"] == user_id
+            assert profile["display_name"] == "Tester"
+            assert profile["avatar_url"] == "https://example.com/a.png"
+            assert profile["bio"] == "Hello"
+            assert profile["followers_count"] == 1
+
+
+def test_profile_not_found():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        for path in ("users/00000000-0000-0000-0000-000000000000", "users/by-login/nosuchuser"):
+            response = requests.get(f"{API_GATEWAY_URL}/passport/{path}", headers={"Authorization": token})
+            assert response.status_code == 404, response.text
+
+
+def test_invalid_profile_fields():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        assert update_me(token, avatar_url="javascript:alert(1)").status_code == 400
+        assert update_me(token, bio="x" * 501).status_code == 400
+
+
+def test_search_users():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password"):
+            assert update_me(token, display_name="Alexander Searchable").status_code == 200
+
+            def search(q):
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/passport/users/search",
+                    headers={"Authorization": token},
+                    params={"q": q},
+                )
+                assert response.status_code == 200, response.text
+                users = response.json()["users"]
+                assert all(not PRIVATE_FIELDS & u.keys() for u in users)
+                return [u["login"] for u in users]
+
+            # Login prefix.
+            assert search("testus")[0] == "testuser"
+            # Exact login first.
+            assert search("otheruser")[0] == "otheruser"
+            # Substring of the display name.
+            assert "testuser" in search("searchab")
+            # A typo.
+            assert "testuser" in search("searchabel")
+
+            response = requests.get(f"{API_GATEWAY_URL}/passport/users/search", headers={"Authorization": token})
+            assert response.status_code == 400, response.text
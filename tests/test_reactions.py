@@ -0,0 +1,78 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, register_and_login
+
+
+def test_reactions():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Reacted Post'"):
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": owner},
+                    json={
+                        "title": "Reacted Post",
+                        "description": "React to me.",
+                        "is_private": False,
+                    },
+                )
+                assert response.status_code == 201, response.text
+                post_id = response.json()["post_id"]
+
+                for token, reaction_type in ((owner, "love"), (other, "like"), (other, "laugh")):
+                    response = requests.put(
+                        f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                        headers={"Authorization": token},
+                        json={"reaction_type": reaction_type},
+                    )
+                    assert response.status_code == 200, response.text
+                    assert response.json()["reaction_type"] == reaction_type
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post_id}",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code == 200, response.text
+                data = response.json()
+                # The second reaction of the other user replaced the first one.
+                assert data["reaction_counts"] == {"love": 1, "laugh": 1}
+                assert data["my_reaction"] == "laugh"
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                    headers={"Authorization": owner},
+                    params={"reaction_type": "laugh"},
+                )
+                assert response.status_code == 200, response.text
+                assert len(response.json()["reactions"]) == 1
+
+                response = requests.delete(
+                    f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code == 200, response.text
+                assert response.json()["success"]
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post_id}",
+                    headers={"Authorization": other},
+                )
+                data = response.json()
+                assert data["reaction_counts"] == {"love": 1}
+                assert "my_reaction" not in data
+
+
+def test_unknown_reaction_type():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Reacted Post'"):
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts",
+                headers={"Authorization": token},
+                json={"title": "Reacted Post", "description": "", "is_private": False},
+            )
+            post_id = response.json()["post_id"]
+            response = requests.put(
+                f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                headers={"Authorization": token},
+                json={"reaction_type": "meh"},
+            )
+            assert response.status_code == 400, response.text
@@ -0,0 +1,83 @@
+import requests
+from utils import API_GATEWAY_URL, register_and_login
+
+
+def login(login, password):
+    response = requests.post(
+        f"{API_GATEWAY_URL}/passport/login",
+        json={"login": login, "password": password},
+    )
+    assert response.status_code == 200, response.text
+    return response.json()
+
+
+def refresh(refresh_token):
+    return requests.post(
+        f"{API_GATEWAY_URL}/passport/refresh",
+        json={"refresh_token": refresh_token},
+    )
+
+
+def test_login_returns_token_pair():
+    with register_and_login("testuser", "mail@example.com", "password"):
+        tokens = login("testuser", "password")
+        assert tokens["token"]
+        assert tokens["refresh_token"]
+        assert tokens["expires_in"] > 0
+
+
+def test_refresh_rotates_tokens():
+    with register_and_login("testuser", "mail@example.com", "password"):
+        tokens = login("testuser", "password")
+
+        response = refresh(tokens["refresh_token"])
+        assert response.status_code == 200, response.text
+        rotated = response.json()
+        assert rotated["refresh_token"] != tokens["refresh_token"]
+        assert rotated["session_id"] == tokens["session_id"]
+
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": rotated["token"]})
+        assert response.status_code == 200, response.text
+
+        response = refresh(rotated["refresh_token"])
+        assert response.status_code == 200, response.text
+
+
+def test_refresh_token_reuse_revokes_family():
+    with register_and_login("testuser", "mail@example.com", "password"):
+        tokens = login("testuser", "password")
+        rotated = refresh(tokens["refresh_token"]).json()
+
+        # Presenting the old refresh token again looks like theft.
+        response = refresh(tokens["refresh_token"])
+        assert response.status_code == 401, response.text
+
+        # Every token of the family is revoked, including the newest ones.
+        response = refresh(rotated["refresh_token"])
+        assert response.status_code == 401, response.text
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": rotated["token"]})
+        assert response.status_code == 401, response.text
+
+
+def test_refresh_reuse_keeps_other_sessions():
+    with register_and_login("testuser", "mail@example.com", "password") as other:
+        tokens = login("testuser", "password")
+        refresh(tokens["refresh_token"])
+        refresh(tokens["refresh_token"])
+
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": other})
+        assert response.status_code == 200, response.text
+
+
+def test_refresh_after_logout():
+    with register_and_login("testuser", "mail@example.com", "password"):
+        tokens = login("testuser", "password")
+        requests.post(f"{API_GATEWAY_URL}/passport/logout", headers={"Authorization": tokens["token"]})
+
+        response = refresh(tokens["refresh_token"])
+        assert response.status_code == 401, response.text
+
+
+def test_refresh_invalid_token():
+    response = refresh("not-a-refresh-token")
+    assert response.status_code == 401, response.text
@@ -0,0 +1,103 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def patch_post(token, post_id, body):
+    response = requests.patch(f"{API_GATEWAY_URL}/posts/{post_id}", headers={"Authorization": token}, json=body)
+    assert response.status_code == 200, response.text
+    return response.json()
+
+
+def test_updates_add_revisions():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Revised Post'"):
+            post = create_post(token, "Revised Post", description="First version.", tags=["history"])
+            assert post["revision"] == 1
+            assert not post["edited"]
+
+            patch_post(token, post["post_id"], {"description": "Second version."})
+            updated = patch_post(token, post["post_id"], {"description": "Third version.", "is_private": True})
+            assert updated["revision"] == 3
+            assert updated["edited"]
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions",
+                headers={"Authorization": token},
+                params={"limit": 2},
+            )
+            assert response.status_code == 200, response.text
+            data = response.json()
+            assert [r["revision"] for r in data["revisions"]] == [3, 2]
+            assert data["revisions"][0]["is_private"]
+            assert data["next_cursor"]
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions",
+                headers={"Authorization": token},
+                params={"limit": 2, "cursor": data["next_cursor"]},
+            )
+            assert response.status_code == 200, response.text
+            data = response.json()
+            [first] = data["revisions"]
+            assert first["revision"] == 1
+            assert first["description"] == "First version."
+            assert first["editor_id"] == post["creator_id"]
+            assert data["next_cursor"] == ""
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions/2",
+                headers={"Authorization": token},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json()["description"] == "Second version."
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions/9",
+                headers={"Authorization": token},
+            )
+            assert response.status_code == 404, response.text
+
+
+def test_restore_revision():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Revised Post%'"):
+            post = create_post(token, "Revised Post", description="First version.", tags=["history"])
+            patch_post(token, post["post_id"], {"title": "Revised Post 2", "is_private": True, "tags": ["kept"]})
+
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions/1/restore",
+                headers={"Authorization": token},
+            )
+            assert response.status_code == 200, response.text
+            restored = response.json()
+            assert restored["title"] == "Revised Post"
+            assert restored["description"] == "First version."
+            assert not restored["is_private"]
+            assert restored["tags"] == ["kept"]
+            assert restored["revision"] == 3
+
+            response = requests.post(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions/2/restore",
+                headers={"Authorization": token, "If-Match": '"1"'},
+            )
+            assert response.status_code == 412, response.text
+
+
+def test_revisions_are_private_to_creator():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Revised Post'"):
+                post = create_post(token, "Revised Post", description="First version.", tags=["history"])
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code == 403, response.text
+                assert response.json()["details"] == [{"reason": "NOT_POST_CREATOR"}]
+
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts/{post['post_id']}/revisions/1/restore",
+                    headers={"Authorization": other},
+                )
+                assert response.status_code == 403, response.text
@@ -0,0 +1,81 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def search(token, **params):
+    response = requests.get(
+        f"{API_GATEWAY_URL}/posts/search",
+        headers={"Authorization": token},
+        params=params,
+    )
+    assert response.status_code == 200, response.text
+    return response.json()
+
+
+def test_search_ranks_and_highlights():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Search %'"):
+            in_title = create_post(token, "Search Kubernetes operators", description="Writing controllers")["post_id"]
+            in_description = create_post(token, "Search notes", description="Deploying to kubernetes <b>clusters</b>")["post_id"]
+            create_post(token, "Search unrelated", description="Nothing here")
+
+            body = search(token, q="kubernetes")
+            ids = [r["post"]["post_id"] for r in body["results"]]
+            # Matches in the title weigh more than in the description.
+            assert ids == [in_title, in_description]
+            assert body["next_cursor"] == ""
+
+            assert body["results"][0]["title_highlight"] == "Search <mark>Kubernetes</mark> operators"
+            snippet = body["results"][1]["description_snippet"]
+            assert "<mark>kubernetes</mark>" in snippet
+            # Post content is escaped.
+            assert "&lt;b&gt;" in snippet
+
+            # Stemming finds other forms of a word.
+            assert [r["post"]["post_id"] for r in search(token, q="operator")["results"]] == [in_title]
+
+
+def test_search_filters_and_pagination():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Search %'"):
+            tagged = create_post(token, "Search golang alpha", tags=["search-test"])["post_id"]
+            untagged = create_post(token, "Search golang beta")["post_id"]
+            create_post(token, "Search golang gamma")
+
+            body = search(token, q="golang", tags="search-test")
+            assert [r["post"]["post_id"] for r in body["results"]] == [tagged]
+
+            body = search(token, q="golang -beta")
+            assert untagged not in [r["post"]["post_id"] for r in body["results"]]
+
+            seen = []
+            cursor = ""
+            while True:
+                body = search(token, q="golang", limit=2, cursor=cursor)
+                seen += [r["post"]["post_id"] for r in body["results"]]
+                cursor = body["next_cursor"]
+                if not cursor:
+                    break
+            assert len(seen) == 3 and len(set(seen)) == 3
+
+            assert search(token, q="golang", created_after="2100-01-01T00:00:00Z")["results"] == []
+
+
+def test_search_respects_privacy():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Search %'"):
+                private_id = create_post(owner, "Search secret diary", is_private=True)["post_id"]
+
+                assert search(other, q="diary")["results"] == []
+                assert [r["post"]["post_id"] for r in search(owner, q="diary")["results"]] == [private_id]
+
+
+def test_search_requires_query():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/posts/search",
+            headers={"Authorization": token},
+        )
+        assert response.status_code == 400, response.text
+        assert response.json()["code"] == "INVALID_ARGUMENT"
@@ -0,0 +1,89 @@
+import requests
+from utils import API_GATEWAY_URL, register_and_login
+
+
+def login(login, password):
+    response = requests.post(
+        f"{API_GATEWAY_URL}/passport/login",
+        json={"login": login, "password": password},
+        headers={"User-Agent": "test-sessions"},
+    )
+    assert response.status_code == 200, response.text
+    return response.json()
+
+
+def test_logout_revokes_token():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": token})
+        assert response.status_code == 200, response.text
+
+        response = requests.post(f"{API_GATEWAY_URL}/passport/logout", headers={"Authorization": token})
+        assert response.status_code == 200, response.text
+
+        # The token has not expired yet but its session is gone.
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": token})
+        assert response.status_code == 401, response.text
+
+
+def test_list_sessions():
+    with register_and_login("testuser", "mail@example.com", "password") as first:
+        second = login("testuser", "password")
+
+        response = requests.get(
+            f"{API_GATEWAY_URL}/passport/sessions",
+            headers={"Authorization": second["token"]},
+        )
+        assert response.status_code == 200, response.text
+        sessions = response.json()["sessions"]
+        assert len(sessions) == 2
+        # Most recent first, and the caller's own session is marked.
+        assert sessions[0]["session_id"] == second["session_id"]
+        assert sessions[0]["current"] is True
+        assert sessions[0]["user_agent"] == "test-sessions"
+        assert sessions[1]["current"] is False
+
+        requests.post(f"{API_GATEWAY_URL}/passport/logout", headers={"Authorization": first})
+        response = requests.get(
+            f"{API_GATEWAY_URL}/passport/sessions",
+            headers={"Authorization": second["token"]},
+        )
+        assert response.status_code == 200, response.text
+        assert [s["session_id"] for s in response.json()["sessions"]] == [second["session_id"]]
+
+
+def test_terminate_other_session():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        other = login("testuser", "password")
+
+        response = requests.delete(
+            f"{API_GATEWAY_URL}/passport/sessions/{other['session_id']}",
+            headers={"Authorization": token},
+        )
+        assert response.status_code == 200, response.text
+
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": other["token"]})
+        assert response.status_code == 401, response.text
+        response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": token})
+        assert response.status_code == 200, response.text
+
+        # Terminating it again finds nothing to terminate.
+        response = requests.delete(
+            f"{API_GATEWAY_URL}/passport/sessions/{other['session_id']}",
+            headers={"Authorization": token},
+        )
+        assert response.status_code == 404, response.text
+
+
+def test_can_not_terminate_foreign_session():
+    with register_and_login("testuser", "mail@example.com", "password") as alice:
+        with register_and_login("otheruser", "other@example.com", "password"):
+            bob = login("otheruser", "password")
+
+            response = requests.delete(
+                f"{API_GATEWAY_URL}/passport/sessions/{bob['session_id']}",
+                headers={"Authorization": alice},
+            )
+            assert response.status_code == 404, response.text
+
+            response = requests.get(f"{API_GATEWAY_URL}/passport/me", headers={"Authorization": bob["token"]})
+            assert response.status_code == 200, response.text
@@ -0,0 +1,143 @@
+import time
+import uuid
+import psycopg
+import requests
+from utils import (
+    API_GATEWAY_URL,
+    POSTS_DATABASE_URL,
+    STATISTICS_DATABASE_URL,
+    WithDeletePosts,
+    WithDeleteStatistics,
+    create_post,
+    register_and_login,
+)
+
+
+def wait_for(query, params, expected, timeout=15):
+    deadline = time.time() + timeout
+    while True:
+        with psycopg.connect(STATISTICS_DATABASE_URL) as conn:
+            with conn.cursor() as cur:
+                cur.execute(query, params)
+                row = cur.fetchone()
+        if row == expected or time.time() > deadline:
+            return row
+        time.sleep(0.5)
+
+
+def varint(value):
+    encoded = bytearray()
+    while True:
+        byte = value & 0x7F
+        value >>= 7
+        if value:
+            encoded.append(byte | 0x80)
+        else:
+            encoded.append(byte)
+            return bytes(encoded)
+
+
+def length_delimited(field, data):
+    if isinstance(data, str):
+        data = data.encode()
+    return varint(field << 3 | 2) + varint(len(data)) + data
+
+
+def comment_created_event(event_id, post_id, actor_user_id):
+    """Encodes a proto.events.PostEvent with a CommentCreated payload."""
+    occurred_at = varint(1 << 3) + varint(int(time.time()))
+    return (
+        length_delimited(1, event_id)
+        + length_delimited(2, occurred_at)
+        + length_delimited(3, post_id)
+        + length_delimited(4, actor_user_id)
+        + length_delimited(13, length_delimited(1, str(uuid.uuid4())))
+    )
+
+
+def publish_raw(post_id, payload):
+    """Sends an event through the posts outbox, as if posts had published it."""
+    with psycopg.connect(POSTS_DATABASE_URL) as conn:
+        with conn.cursor() as cur:
+            cur.execute("INSERT INTO outbox (post_id, payload) VALUES (%s, %s)", (post_id, payload))
+            conn.commit()
+
+
+def test_events_change_counters_and_trending():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Statistics Post'"):
+                post_id = create_post(owner, "Statistics Post")["post_id"]
+
+                with WithDeleteStatistics(f"DELETE FROM events WHERE post_id = '{post_id}'"):
+                    with WithDeleteStatistics(f"DELETE FROM post_stats WHERE post_id = '{post_id}'"):
+                        with WithDeleteStatistics(f"DELETE FROM post_activity WHERE post_id = '{post_id}'"):
+                            for token in (owner, other):
+                                response = requests.put(
+                                    f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+                                    headers={"Authorization": token},
+                                    json={"reaction_type": "like"},
+                                )
+                                assert response.status_code == 200, response.text
+                            response = requests.post(
+                                f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                                headers={"Authorization": other},
+                                json={"content": "Counted"},
+                            )
+                            assert response.status_code == 201, response.text
+
+                            assert wait_for(
+                                "SELECT likes, comments FROM post_stats WHERE post_id = %s", (post_id,), (2, 1)
+                            ) == (2, 1)
+                            assert wait_for(
+                                "SELECT SUM(likes), SUM(comments) FROM post_activity WHERE post_id = %s",
+                                (post_id,),
+                                (2, 1),
+                            ) == (2, 1)
+
+                            # The ranking is refreshed periodically.
+                            deadline = time.time() + 30
+                            while True:
+                                response = requests.get(
+                                    f"{API_GATEWAY_URL}/posts/trending",
+                                    headers={"Authorization": owner},
+                                    params={"limit": 100},
+                                )
+                                assert response.status_code == 200, response.text
+                                ids = [p["post_id"] for p in response.json()["posts"]]
+                                if post_id in ids or time.time() > deadline:
+                                    break
+                                time.sleep(1)
+                            assert post_id in ids
+
+
+def test_redelivered_event_is_counted_once():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Redelivered Post'"):
+            post_id = create_post(owner, "Redelivered Post")["post_id"]
+            response = requests.get(f"{API_GATEWAY_URL}/posts/{post_id}", headers={"Authorization": owner})
+            creator_id = response.json()["creator_id"]
+
+            with WithDeleteStatistics(f"DELETE FROM events WHERE post_id = '{post_id}'"):
+                with WithDeleteStatistics(f"DELETE FROM post_stats WHERE post_id = '{post_id}'"):
+                    with WithDeleteStatistics(f"DELETE FROM post_activity WHERE post_id = '{post_id}'"):
+                        duplicate = comment_created_event(str(uuid.uuid4()), post_id, creator_id)
+                        publish_raw(post_id, duplicate)
+                        publish_raw(post_id, duplicate)
+                        last_event_id = str(uuid.uuid4())
+                        publish_raw(post_id, comment_created_event(last_event_id, post_id, creator_id))
+
+                        # Events of a post are consumed in order, so once the
+                        # last one is recorded the duplicate has been seen too.
+                        assert wait_for(
+                            "SELECT COUNT(*) FROM events WHERE event_id = %s", (last_event_id,), (1,)
+                        ) == (1,)
+                        assert wait_for(
+                            "SELECT comments FROM post_stats WHERE post_id = %s", (post_id,), (2,), timeout=0
+                        ) == (2,)
+                        assert wait_for(
+                            "SELECT COUNT(*) FROM events WHERE post_id = %s", (post_id,), (2,), timeout=0
+                        ) == (2,)
+                        assert wait_for(
+                            "SELECT SUM(comments) FROM post_activity WHERE post_id = %s", (post_id,), (2,), timeout=0
+                        ) == (2,)
@@ -0,0 +1,98 @@
+import requests
+from utils import API_GATEWAY_URL, register_and_login
+
+
+def user_id_of(login, password):
+    response = requests.post(
+        f"{API_GATEWAY_URL}/passport/login",
+        json={"login": login, "password": password},
+    )
+    assert response.status_code == 200, response.text
+    return response.json()["user_id"]
+
+
+def test_follow_and_unfollow():
+    with register_and_login("testuser", "mail@example.com", "password") as alice:
+        with register_and_login("otheruser", "other@example.com", "password") as bob:
+            alice_id = user_id_of("testuser", "password")
+            bob_id = user_id_of("otheruser", "password")
+
+            response = requests.post(
+                f"{API_GATEWAY_URL}/passport/users/{bob_id}/follow",
+                headers={"Authorization": alice},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json() == {"following": True}
+
+            # Following twice is a no-op.
+            response = requests.post(
+                f"{API_GATEWAY_URL}/passport/users/{bob_id}/follow",
+                headers={"Authorization": alice},
+            )
+            assert response.status_code == 200, response.text
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/passport/users/{alice_id}/following/{bob_id}",
+                headers={"Authorization": bob},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json() == {"following": True}
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/passport/users/{bob_id}/followers",
+                headers={"Authorization": bob},
+            )
+            assert response.status_code == 200, response.text
+            data = response.json()
+            assert [u["user_id"] for u in data["users"]] == [alice_id]
+            assert data["users"][0]["login"] == "testuser"
+            assert "email" not in data["users"][0]
+            assert data["total_count"] == 1
+            assert data["next_cursor"] == ""
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/passport/users/{alice_id}/following",
+                headers={"Authorization": bob},
+            )
+            assert response.status_code == 200, response.text
+            assert [u["user_id"] for u in response.json()["users"]] == [bob_id]
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/passport/users/{bob_id}/follow_counts",
+                headers={"Authorization": alice},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json() == {"followers_count": 1, "following_count": 0}
+
+            response = requests.delete(
+                f"{API_GATEWAY_URL}/passport/users/{bob_id}/follow",
+                headers={"Authorization": alice},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json() == {"following": False}
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/passport/users/{alice_id}/following/{bob_id}",
+                headers={"Authorization": alice},
+            )
+            assert response.json() == {"following": False}
+
+
+def test_follow_errors():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        user_id = user_id_of("testuser", "password")
+
+        response = requests.post(
+            f"{API_GATEWAY_URL}/passport/users/{user_id}/follow",
+            headers={"Authorization": token},
+        )
+        assert response.status_code == 400, response.text
+
+        response = requests.post(
+            f"{API_GATEWAY_URL}/passport/users/00000000-0000-0000-0000-000000000000/follow",
+            headers={"Authorization": token},
+        )
+        assert response.status_code == 404, response.text
+
+        response = requests.post(f"{API_GATEWAY_URL}/passport/users/{user_id}/follow")
+        assert response.status_code == 401, response.text
@@ -0,0 +1,68 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def test_tags_on_create_and_update():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title = 'Tagged Post'"):
+            post = create_post(token, "Tagged Post", tags=["#Go", "go", " databases "])
+            assert post["tags"] == ["go", "databases"]
+
+            response = requests.put(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}",
+                headers={"Authorization": token},
+                json={
+                    "title": "Tagged Post",
+                    "description": "Tagged post.",
+                    "is_private": False,
+                    "tags": ["rust"],
+                },
+            )
+            assert response.status_code == 200, response.text
+            assert response.json()["tags"] == ["rust"]
+
+            response = requests.get(
+                f"{API_GATEWAY_URL}/posts/{post['post_id']}",
+                headers={"Authorization": token},
+            )
+            assert response.status_code == 200, response.text
+            assert response.json()["tags"] == ["rust"]
+
+
+def test_filter_posts_by_tags():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Tag Filter %'"):
+            create_post(token, "Tag Filter A", tags=["tagfilter-a"])
+            create_post(token, "Tag Filter B", tags=["tagfilter-b"])
+            create_post(token, "Tag Filter AB", tags=["tagfilter-a", "tagfilter-b"])
+
+            def titles(tag_match):
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": token},
+                    params={"limit": 100, "tags": "tagfilter-a,tagfilter-b", "tag_match": tag_match},
+                )
+                assert response.status_code == 200, response.text
+                return sorted(p["title"] for p in response.json()["posts"])
+
+            assert titles("any") == ["Tag Filter A", "Tag Filter AB", "Tag Filter B"]
+            assert titles("all") == ["Tag Filter AB"]
+
+
+def test_popular_tags_skip_private_posts():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Popular %'"):
+                create_post(owner, "Popular 1", tags=["popular-public"])
+                create_post(owner, "Popular 2", tags=["popular-public"])
+                create_post(owner, "Popular 3", tags=["popular-secret"], is_private=True)
+
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/tags/popular",
+                    headers={"Authorization": other},
+                    params={"limit": 100},
+                )
+                assert response.status_code == 200, response.text
+                tags = {t["name"]: t["post_count"] for t in response.json()["tags"]}
+                assert tags["popular-public"] == 2
+                assert "popular-secret" not in tags
@@ -0,0 +1,60 @@
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, create_post, register_and_login
+
+
+def test_deleted_post_is_hidden_and_can_be_restored():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Trashed Post%'"):
+                post_id = create_post(token, "Trashed Post", tags=["trash-test"])["post_id"]
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts/{post_id}/comments",
+                    headers={"Authorization": other},
+                    json={"content": "Still here"},
+                )
+                assert response.status_code == 201, response.text
+
+                response = requests.delete(f"{API_GATEWAY_URL}/posts/{post_id}", headers={"Authorization": token})
+                assert response.status_code == 200, response.text
+
+                for headers in ({"Authorization": token}, {"Authorization": other}):
+                    response = requests.get(f"{API_GATEWAY_URL}/posts/{post_id}", headers=headers)
+                    assert response.status_code == 404, response.text
+                    response = requests.get(f"{API_GATEWAY_URL}/posts/{post_id}/comments", headers=headers)
+                    assert response.status_code == 404, response.text
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts", headers={"Authorization": token}, params={"tags": "trash-test"}
+                )
+                assert response.status_code == 200, response.text
+                assert response.json()["posts"] == []
+
+                # Deleting twice or editing a deleted post finds nothing.
+                response = requests.delete(f"{API_GATEWAY_URL}/posts/{post_id}", headers={"Authorization": token})
+                assert response.status_code == 404, response.text
+                response = requests.patch(
+                    f"{API_GATEWAY_URL}/posts/{post_id}", headers={"Authorization": token}, json={"title": "Trashed Post 2"}
+                )
+                assert response.status_code == 404, response.text
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/trash", headers={"Authorization": token})
+                assert response.status_code == 200, response.text
+                [deleted] = response.json()["posts"]
+                assert deleted["post"]["post_id"] == post_id
+                assert deleted["purge_at"] > deleted["deleted_at"]
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/trash", headers={"Authorization": other})
+                assert response.status_code == 200, response.text
+                assert response.json()["posts"] == []
+                response = requests.post(f"{API_GATEWAY_URL}/posts/{post_id}/restore", headers={"Authorization": other})
+                assert response.status_code == 404, response.text
+
+                response = requests.post(f"{API_GATEWAY_URL}/posts/{post_id}/restore", headers={"Authorization": token})
+                assert response.status_code == 200, response.text
+                assert response.json()["tags"] == ["trash-test"]
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/{post_id}/comments", headers={"Authorization": other})
+                assert response.status_code == 200, response.text
+                assert [c["content"] for c in response.json()["comments"]] == ["Still here"]
+
+                response = requests.get(f"{API_GATEWAY_URL}/posts/trash", headers={"Authorization": token})
+                assert response.json()["posts"] == []
@@ -0,0 +1,57 @@
+import time
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, WithDeleteStatistics, create_post, register_and_login
+
+
+def react(token, post_id):
+    response = requests.put(
+        f"{API_GATEWAY_URL}/posts/{post_id}/reactions",
+        headers={"Authorization": token},
+        json={"reaction_type": "like"},
+    )
+    assert response.status_code == 200, response.text
+
+
+def trending_ids(token):
+    response = requests.get(
+        f"{API_GATEWAY_URL}/posts/trending",
+        headers={"Authorization": token},
+        params={"limit": 100},
+    )
+    assert response.status_code == 200, response.text
+    return [p["post_id"] for p in response.json()["posts"]]
+
+
+def test_trending_includes_only_public_posts():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title IN ('Trending Public', 'Trending Private')"):
+                public_id = create_post(owner, "Trending Public")["post_id"]
+                private_id = create_post(owner, "Trending Private", is_private=True)["post_id"]
+
+                with WithDeleteStatistics(f"DELETE FROM events WHERE post_id IN ('{public_id}', '{private_id}')"):
+                    with WithDeleteStatistics(f"DELETE FROM post_stats WHERE post_id IN ('{public_id}', '{private_id}')"):
+                        with WithDeleteStatistics(f"DELETE FROM post_activity WHERE post_id IN ('{public_id}', '{private_id}')"):
+                            for token in (owner, other):
+                                react(token, public_id)
+                            react(owner, private_id)
+
+                            # The ranking is refreshed periodically.
+                            deadline = time.time() + 30
+                            ids = trending_ids(owner)
+                            while public_id not in ids and time.time() < deadline:
+                                time.sleep(1)
+                                ids = trending_ids(owner)
+
+                            assert public_id in ids
+                            assert private_id not in ids
+
+
+def test_trending_invalid_limit():
+    with register_and_login("testuser", "mail@example.com", "password") as token:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/posts/trending",
+            headers={"Authorization": token},
+            params={"limit": "many"},
+        )
+        assert response.status_code == 400, response.text
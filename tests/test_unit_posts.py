@@ -1,35 +1,5 @@
 import requests
-from utils import API_GATEWAY_URL, WithDeletePassport, WithDeletePosts
-from contextlib import contextmanager
-
-
-@contextmanager
-def register_and_login(login, email, password):
-    with WithDeletePassport(f"DELETE FROM users WHERE login = '{login}' OR email = '{email}'"):
-        # Register user
-        requests.post(
-            f"{API_GATEWAY_URL}/passport/register",
-            json={
-                "login": login,
-                "email": email,
-                "password": password,
-                "name": "Test",
-                "surname": "User",
-                "date_of_birth": "1990-01-01",
-                "phone_number": "+1234567890",
-            },
-        )
-
-        # Login user
-        response = requests.post(
-            f"{API_GATEWAY_URL}/passport/login",
-            json={"login": login, "password": password},
-        )
-        token = response.json()["token"]
-        try:
-            yield token
-        finally:
-            pass  # Cleanup logic if needed
+from utils import API_GATEWAY_URL, WithDeletePosts, register_and_login
 
 
 def test_create_post():
@@ -194,3 +164,53 @@ def test_get_posts():
             assert "posts" in data, f"Response JSON does not contain 'posts': {data}"
             assert isinstance(data["posts"], list), f"'posts' is not a list: {data}"
             assert len(data["posts"]) >= 2, f"Expected at least 2 posts, got: {data}"
+
+
+def test_get_posts_pagination():
+    login = "testuser"
+    email = "mail@example.com"
+    password = "password"
+
+    with register_and_login(login, email, password) as token:
+        with WithDeletePosts("DELETE FROM posts WHERE title LIKE 'Paged Post %'"):
+            titles = [f"Paged Post {i}" for i in range(5)]
+            for title in titles:
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": token},
+                    json={
+                        "title": title,
+                        "description": "Paged post.",
+                        "is_private": False,
+                        "tags": ["paging-test"],
+                    },
+                )
+                assert response.status_code == 201, response.text
+
+            def get_page(**params):
+                response = requests.get(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": token},
+                    params={"limit": 2, "tags": "paging-test", **params},
+                )
+                assert response.status_code == 200, response.text
+                return response.json()
+
+            pages = [get_page()]
+            while pages[-1]["has_more"]:
+                pages.append(get_page(page_token=pages[-1]["next_page_token"]))
+
+            assert [[p["title"] for p in page["posts"]] for page in pages] == [
+                titles[0:2],
+                titles[2:4],
+                titles[4:5],
+            ]
+            assert all(page["total_count"] == 5 for page in pages)
+            assert pages[0]["prev_page_token"] == ""
+            assert pages[-1]["next_page_token"] == ""
+
+            # Paging backward from the last page returns the previous one.
+            previous = get_page(page_token=pages[-1]["prev_page_token"])
+            assert [p["title"] for p in previous["posts"]] == titles[2:4]
+            assert previous["next_page_token"] != ""
+            assert previous["prev_page_token"] != ""
@@ -0,0 +1,50 @@
+import time
+import requests
+from utils import API_GATEWAY_URL, WithDeletePosts, WithDeleteStatistics, register_and_login
+
+
+def get_post(token, post_id):
+    response = requests.get(
+        f"{API_GATEWAY_URL}/posts/{post_id}",
+        headers={"Authorization": token},
+    )
+    assert response.status_code == 200, response.text
+    return response.json()
+
+
+def wait_for_view_count(token, post_id, expected, timeout=15):
+    deadline = time.time() + timeout
+    while True:
+        response = requests.get(
+            f"{API_GATEWAY_URL}/posts",
+            headers={"Authorization": token},
+            params={"tags": "views-test"},
+        )
+        assert response.status_code == 200, response.text
+        view_count = next(p for p in response.json()["posts"] if p["post_id"] == post_id).get("view_count")
+        if view_count == expected or time.time() > deadline:
+            return view_count
+        time.sleep(0.5)
+
+
+def test_views_are_counted_once_per_user():
+    with register_and_login("testuser", "mail@example.com", "password") as owner:
+        with register_and_login("otheruser", "other@example.com", "password") as other:
+            with WithDeletePosts("DELETE FROM posts WHERE title = 'Viewed Post'"):
+                response = requests.post(
+                    f"{API_GATEWAY_URL}/posts",
+                    headers={"Authorization": owner},
+                    json={"title": "Viewed Post", "description": "", "is_private": False, "tags": ["views-test"]},
+                )
+                assert response.status_code == 201, response.text
+                post_id = response.json()["post_id"]
+
+                with WithDeleteStatistics(f"DELETE FROM events WHERE post_id = '{post_id}'"):
+                    with WithDeleteStatistics(f"DELETE FROM post_stats WHERE post_id = '{post_id}'"):
+                        get_post(other, post_id)
+                        get_post(other, post_id)
+                        get_post(owner, post_id)
+
+                        # Listing records no views.
+                        assert wait_for_view_count(owner, post_id, 2) == 2
+                        assert get_post(owner, post_id)["view_count"] == 2
@@ -1,9 +1,12 @@
 import os
 import psycopg
+import requests
+from contextlib import contextmanager
 
 API_GATEWAY_URL = os.getenv("API_GATEWAY_URL")
 PASSPORT_DATABASE_URL = os.getenv("PASSPORT_DATABASE_URL")
 POSTS_DATABASE_URL = os.getenv("POSTS_DATABASE_URL")
+STATISTICS_DATABASE_URL = os.getenv("STATISTICS_DATABASE_URL")
 
 
 class WithDelete:
@@ -28,3 +31,47 @@ class WithDeletePassport(WithDelete):
 
 class WithDeletePosts(WithDelete):
     DATABASE_URL = POSTS_DATABASE_URL
+
+
+class WithDeleteStatistics(WithDelete):
+    DATABASE_URL = STATISTICS_DATABASE_URL
+
+
+def create_post(token, title, **fields):
+    """Creates a public post and returns it; fields are added to the request."""
+    response = requests.post(
+        f"{API_GATEWAY_URL}/posts",
+        headers={"Authorization": token},
+        json={"title": title, "description": "", "is_private": False, **fields},
+    )
+    assert response.status_code == 201, response.text
+    return response.json()
+
+
+@contextmanager
+def register_and_login(login, email, password):
+    with WithDeletePassport(f"DELETE FROM users WHERE login = '{login}' OR email = '{email}'"):
+        # Register user
+        requests.post(
+            f"{API_GATEWAY_URL}/passport/register",
+            json={
+                "login": login,
+                "email": email,
+                "password": password,
+                "name": "Test",
+                "surname": "User",
+                "date_of_birth": "1990-01-01",
+                "phone_number": "+1234567890",
+            },
+        )
+
+        # Login user
+        response = requests.post(
+            f"{API_GATEWAY_URL}/passport/login",
+            json={"login": login, "password": password},
+        )
+        token = response.json()["token"]
+        try:
+            yield token
+        finally:
+            pass  # Cleanup logic if needed